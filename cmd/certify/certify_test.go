@@ -2,16 +2,21 @@ package main
 
 import (
 	"flag"
+	"strings"
 	"testing"
 
 	"github.com/wongma7/csi-certify/pkg/certify"
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
 	"k8s.io/kubernetes/test/e2e/framework"
+
+	// Register the TestDrivers that are part of this repo.
+	_ "github.com/wongma7/csi-certify/pkg/certify/driver"
 )
 
 var customTestDriver string
 
 func init() {
-	flag.StringVar(&customTestDriver, "testdriver", "", "the testdriver implementation that you want to run (should be one of the implementations defined in CSITestDrivers)")
+	flag.StringVar(&customTestDriver, "testdriver", "", "the testdriver implementation that you want to run, one of: "+strings.Join(registry.Names(), ", "))
 	framework.HandleFlags()
 	framework.AfterReadingAllFlags(&framework.TestContext)
 }
@@ -0,0 +1,66 @@
+// csi-mock-proxy runs as sidecar of the CSI mock driver and lets
+// individual CSI calls fail, run slowly or return empty responses:
+//
+//	csi-mock-proxy --listen=/csi/csi.sock --target=/csi/mock.sock \
+//		--error=/csi.v1.Node/NodePublishVolume=Internal \
+//		--delay=/csi.v1.Controller/CreateVolume=90s \
+//		--empty=/csi.v1.Node/NodeGetCapabilities
+//
+// All other calls get forwarded to the driver unchanged.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wongma7/csi-certify/pkg/certify/mockproxy"
+	"google.golang.org/grpc"
+)
+
+// stringList is a flag which may be given more than once.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func main() {
+	var errs, delays, empty stringList
+	listen := flag.String("listen", "/csi/csi.sock", "Unix domain socket to listen on")
+	target := flag.String("target", "/csi/mock.sock", "Unix domain socket of the CSI driver")
+	flag.Var(&errs, "error", "<method>=<gRPC status code name>, fails the method, may be given more than once")
+	flag.Var(&delays, "delay", "<method>=<duration>, delays the method, may be given more than once")
+	flag.Var(&empty, "empty", "<method>, returns an empty response for the method, may be given more than once")
+	flag.Parse()
+
+	rules, err := mockproxy.ParseRules(errs, delays, empty)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conn, err := grpc.Dial(*target, grpc.WithInsecure(), grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", addr, timeout)
+	}))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	os.Remove(*listen)
+	listener, err := net.Listen("unix", *listen)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("forwarding %s to %s with %+v", *listen, *target, rules)
+	if err := mockproxy.NewServer(conn, rules).Serve(listener); err != nil {
+		log.Fatal(err)
+	}
+}
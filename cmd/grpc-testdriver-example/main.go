@@ -0,0 +1,40 @@
+// grpc-testdriver-example serves the reference gRPC TestDriver for the
+// HostPath CSI plugin. Run it next to csi-certify with:
+//
+//	grpc-testdriver-example --listen=unix:///tmp/testdriver.sock &
+//	go test ./cmd/certify -ginkgo.v --kubeconfig=... --grpc-testdriver=unix:///tmp/testdriver.sock
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/wongma7/csi-certify/pkg/certify/external-grpc/example"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	listen := flag.String("listen", "unix:///tmp/testdriver.sock", "unix:///path/to/socket or host:port to listen on")
+	driverName := flag.String("driver-name", "csi-hostpath", "name of the installed CSI driver")
+	flag.Parse()
+
+	network, address := "tcp", *listen
+	if strings.HasPrefix(address, "unix://") {
+		network, address = "unix", strings.TrimPrefix(address, "unix://")
+		os.Remove(address)
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	example.NewServer(*driverName).Register(server)
+	log.Printf("serving gRPC TestDriver for %s on %s", *driverName, *listen)
+	if err := server.Serve(listener); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,102 @@
+// scaffold generates the skeleton of a Go TestDriver. Interfaces that
+// are not selected with flags are asked for interactively:
+//
+//	go run ./cmd/scaffold --name=mydriver --driver-name=csi-mydriver
+//
+// Alternatively it converts a DriverDefinition into a Go TestDriver:
+//
+//	go run ./cmd/scaffold --name=mydriver --from-driverdef=driver-def.yaml
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wongma7/csi-certify/pkg/certify/scaffold"
+	"github.com/wongma7/csi-certify/pkg/certify/utils"
+)
+
+func main() {
+	var o scaffold.Options
+	root := flag.String("root", ".", "root directory of the csi-certify repo")
+	driverDef := flag.String("from-driverdef", "", "generate a TestDriver that behaves like --driverdef with this .yaml or .json file")
+	flag.StringVar(&o.Name, "name", "", "name of the TestDriver, used for --testdriver and file names")
+	flag.StringVar(&o.DriverName, "driver-name", "", "name of the CSI driver, csi-<name> by default")
+	flag.BoolVar(&o.DynamicPV, "dynamic", false, "implement DynamicPVTestDriver")
+	flag.BoolVar(&o.PreprovisionedPV, "preprovisioned", false, "implement PreprovisionedPVTestDriver")
+	flag.BoolVar(&o.InlineVolume, "inline", false, "implement InlineVolumeTestDriver")
+	flag.BoolVar(&o.Snapshots, "snapshots", false, "implement SnapshottableTestDriver")
+	flag.Parse()
+
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	in := bufio.NewReader(os.Stdin)
+	if !set["name"] {
+		o.Name = ask(in, "Name of the TestDriver (lower case letters and digits)")
+	}
+
+	if *driverDef != "" {
+		definition, err := utils.LoadDriverDefinition(*driverDef)
+		if err != nil {
+			fail(err)
+		}
+		files, err := scaffold.FilesFromDefinition(o.Name, definition)
+		if err != nil {
+			fail(err)
+		}
+		write(*root, files, o.Name)
+		return
+	}
+
+	for _, question := range []struct {
+		flag   string
+		prompt string
+		value  *bool
+	}{
+		{"dynamic", "Does the driver support dynamic provisioning (DynamicPVTestDriver)?", &o.DynamicPV},
+		{"preprovisioned", "Does the driver support pre-provisioned PVs (PreprovisionedPVTestDriver)?", &o.PreprovisionedPV},
+		{"inline", "Does the driver support inline volumes (InlineVolumeTestDriver)?", &o.InlineVolume},
+		{"snapshots", "Does the driver support snapshots (SnapshottableTestDriver)?", &o.Snapshots},
+	} {
+		if !set[question.flag] {
+			answer := strings.ToLower(ask(in, question.prompt+" [y/N]"))
+			*question.value = answer == "y" || answer == "yes"
+		}
+	}
+
+	files, err := scaffold.Files(o)
+	if err != nil {
+		fail(err)
+	}
+	write(*root, files, o.Name)
+}
+
+func write(root string, files map[string][]byte, name string) {
+	paths, err := scaffold.Write(root, files)
+	if err != nil {
+		fail(err)
+	}
+	for _, path := range paths {
+		fmt.Printf("created %s\n", path)
+	}
+	fmt.Printf("Fill in the TODOs, then run the tests with --testdriver=%s.\n", name)
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+	os.Exit(1)
+}
+
+func ask(in *bufio.Reader, prompt string) string {
+	fmt.Printf("%s: ", prompt)
+	answer, err := in.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintf(os.Stderr, "\nERROR: reading answer: %v\n", err)
+		os.Exit(1)
+	}
+	return strings.TrimSpace(answer)
+}
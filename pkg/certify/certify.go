@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/wongma7/csi-certify/pkg/certify/external"
 	"github.com/wongma7/csi-certify/pkg/certify/external-bash"
+	"github.com/wongma7/csi-certify/pkg/certify/external-grpc"
 	customTest "github.com/wongma7/csi-certify/pkg/certify/test"
 )
 
@@ -17,12 +18,23 @@ func Test(t *testing.T, customTestDriver string) {
 		Run tests using user's own testDriver implementation if the --testdriver flag is given
 		Run tests using user's driverDefinition YAML file if the --driverdef flag is given
 		Run tests using an external testDriver (bash script) if the --external-testdriver flag is provided
-		If any of the three flags are not given, run all testDriver implementations defined in certify/driver
+		Run tests using a testDriver served via gRPC if the --grpc-testdriver flag is given
+		If none of these flags are not given, run all testDriver implementations defined in certify/driver
 	*/
 
-	if external.RunCustomTestDriver && externalBash.RunCustomTestDriver {
-		customTest.RunCustomTestDriver(customTestDriver)
+	if external.RunCustomTestDriver && externalBash.RunCustomTestDriver && externalGRPC.RunCustomTestDriver {
+		if err := customTest.RunCustomTestDriver(customTestDriver); err != nil {
+			t.Fatal(err)
+		}
 	}
 
-	RunSpecs(t, "CSI Suite")
+	r, err := customReporters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	RunSpecsWithDefaultAndCustomReporters(t, "CSI Suite", r)
+
+	if err := externalGRPC.Close(); err != nil {
+		t.Errorf("closing gRPC TestDriver connection: %v", err)
+	}
 }
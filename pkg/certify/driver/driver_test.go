@@ -0,0 +1,18 @@
+package driver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/wongma7/csi-certify/pkg/certify/fake"
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
+)
+
+func TestSkipUnsupportedTest(t *testing.T) {
+	for _, entry := range registry.Entries() {
+		t.Run(entry.Name, func(t *testing.T) {
+			report := fake.Report(entry.Factory())
+			fake.CheckGolden(t, filepath.Join("testdata", entry.Name+".golden"), report)
+		})
+	}
+}
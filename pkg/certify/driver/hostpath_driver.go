@@ -5,6 +5,7 @@ import (
 	"math/rand"
 
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubernetes/test/e2e/framework"
 	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
@@ -12,8 +13,17 @@ import (
 	"k8s.io/kubernetes/test/e2e/storage/utils"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
 )
 
+func init() {
+	registry.Register("hostpath", InitHostPathCSIDriver, registry.Metadata{
+		Description:  "HostPath CSI plugin, deployed on a single node for each test",
+		ParallelSafe: true,
+	})
+}
+
 var HostPathDriver func() testsuites.TestDriver
 
 // hostpathCSI
@@ -29,11 +39,18 @@ func initHostPathCSIDriver(name string, manifests ...string) testsuites.TestDriv
 			Name:        name,
 			FeatureTag:  "",
 			MaxFileSize: testpatterns.FileSizeMedium,
+			// Filesystem volumes are directories on the node, so
+			// the plugin cannot format them with a specific fsType.
 			SupportedFsType: sets.NewString(
 				"", // Default fsType
 			),
 			Capabilities: map[testsuites.Capability]bool{
 				testsuites.CapPersistence: true,
+				testsuites.CapDataSource:  true,
+				testsuites.CapBlock:       true,
+				testsuites.CapExec:        true,
+				// All pods run on the same node, see PrepareTest.
+				testsuites.CapMultiPODs: true,
 			},
 		},
 		manifests: manifests,
@@ -42,6 +59,8 @@ func initHostPathCSIDriver(name string, manifests ...string) testsuites.TestDriv
 
 var _ testsuites.TestDriver = &hostpathCSIDriver{}
 var _ testsuites.DynamicPVTestDriver = &hostpathCSIDriver{}
+var _ testsuites.SnapshottableTestDriver = &hostpathCSIDriver{}
+var _ testUtils.ControllerPluginTestDriver = &hostpathCSIDriver{}
 
 // InitHostPathCSIDriver returns hostpathCSIDriver that implements TestDriver interface
 func InitHostPathCSIDriver() testsuites.TestDriver {
@@ -53,6 +72,8 @@ func InitHostPathCSIDriver() testsuites.TestDriver {
 		"hostpath/driver-registrar-rbac.yaml",
 		"hostpath/e2e-test-rbac.yaml",
 		"hostpath/provisioner-rbac.yaml",
+		"hostpath/csi-hostpath-snapshotter.yaml",
+		"hostpath/snapshotter-rbac.yaml",
 	)
 }
 
@@ -60,6 +81,10 @@ func (h *hostpathCSIDriver) GetDriverInfo() *testsuites.DriverInfo {
 	return &h.driverInfo
 }
 
+// SkipUnsupportedTest has nothing to check beyond what the testsuites
+// already derive from the implemented interfaces and the DriverInfo:
+// volume types other than dynamic provisioning, fsTypes other than the
+// default and (without CapBlock) raw block volumes get skipped there.
 func (h *hostpathCSIDriver) SkipUnsupportedTest(pattern testpatterns.TestPattern) {
 }
 
@@ -72,10 +97,42 @@ func (h *hostpathCSIDriver) GetDynamicProvisionStorageClass(config *testsuites.P
 	return testsuites.GetStorageClass(provisioner, parameters, nil, ns, suffix)
 }
 
+func (h *hostpathCSIDriver) GetSnapshotClass(config *testsuites.PerTestConfig) *unstructured.Unstructured {
+	snapshotter := config.GetUniqueDriverName()
+	parameters := map[string]string{}
+	ns := config.Framework.Namespace.Name
+	suffix := fmt.Sprintf("%s-vsc", snapshotter)
+
+	return testsuites.GetSnapshotClass(snapshotter, parameters, ns, suffix)
+}
+
 func (h *hostpathCSIDriver) GetClaimSize() string {
 	return "5Gi"
 }
 
+// The plugin itself keeps its volumes in memory and runs in a separate
+// pod, so only the provisioner may get restarted.
+func (h *hostpathCSIDriver) GetControllerPlugin(config *testsuites.PerTestConfig) testUtils.PodSelector {
+	return testUtils.PodSelector{
+		Namespace:     config.Framework.Namespace.Name,
+		LabelSelector: "app=csi-hostpath-provisioner",
+	}
+}
+
+// GetVolumeLister lists the volume directories of the plugin. Their
+// names are UUIDs; snapshots are stored next to them as .tgz files.
+// Older plugin releases keep them in /tmp.
+func (h *hostpathCSIDriver) GetVolumeLister(config *testsuites.PerTestConfig) testUtils.PodCommand {
+	return testUtils.PodCommand{
+		Pods: testUtils.PodSelector{
+			Namespace:     config.Framework.Namespace.Name,
+			LabelSelector: "app=csi-hostpathplugin",
+		},
+		Container: "hostpath",
+		Command:   "{ cd /csi-data-dir 2>/dev/null || cd /tmp; } && ls -1 | grep -E '^[0-9a-f]{8}-([0-9a-f]{4}-){3}[0-9a-f]{12}$' || true",
+	}
+}
+
 func (h *hostpathCSIDriver) PrepareTest(f *framework.Framework) (*testsuites.PerTestConfig, func()) {
 	By(fmt.Sprintf("deploying %s driver", h.driverInfo.Name))
 	cancel := testsuites.StartPodLogs(f)
@@ -91,18 +148,20 @@ func (h *hostpathCSIDriver) PrepareTest(f *framework.Framework) (*testsuites.Per
 		ClientNodeName: nodeName,
 	}
 
-	// TODO (?): the storage.csi.image.version and storage.csi.image.registry
-	// settings are ignored for this test. We could patch the image definitions.
 	o := utils.PatchCSIOptions{
 		OldDriverName:            h.driverInfo.Name,
 		NewDriverName:            config.GetUniqueDriverName(),
 		DriverContainerName:      "hostpath",
 		DriverContainerArguments: []string{"--drivername=" + config.GetUniqueDriverName()},
 		ProvisionerContainerName: "csi-provisioner",
+		SnapshotterContainerName: "csi-snapshotter",
 		NodeName:                 nodeName,
 	}
 	cleanup, err := config.Framework.CreateFromManifests(func(item interface{}) error {
-		return utils.PatchCSIDeployment(config.Framework, o, item)
+		if err := utils.PatchCSIDeployment(config.Framework, o, item); err != nil {
+			return err
+		}
+		return testUtils.PatchImages(item)
 	},
 		h.manifests...)
 	if err != nil {
@@ -0,0 +1,251 @@
+package driver
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	apps "k8s.io/api/apps/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+)
+
+// mockCreateVolumeDelay is how long CreateVolume takes in the slow
+// variant of the mock driver. It is below the timeout for binding a
+// PVC, so tests still pass, just slowly.
+const mockCreateVolumeDelay = 90 * time.Second
+
+// MockResult is the outcome of a single test.
+type MockResult string
+
+const (
+	MockPassed  MockResult = "passed"
+	MockFailed  MockResult = "failed"
+	MockSkipped MockResult = "skipped"
+)
+
+// MockExpectation is the result that one test is expected to have
+// when running against a variant of the mock driver.
+type MockExpectation struct {
+	// Test is the name of the test below the "[Driver: csi-mock]"
+	// context, for example "[Testpattern: Dynamic PV (default fs)]
+	// provisioning should provision storage with defaults".
+	Test   string
+	Result MockResult
+	// MinDuration is how long the test takes at least.
+	MinDuration time.Duration
+}
+
+// mockBehavior describes how a variant of the mock driver deviates from
+// a working CSI driver and how the tests are expected to react.
+type mockBehavior struct {
+	name        string
+	description string
+	expected    []MockExpectation
+
+	// proxyArgs are added to csi-mock-proxy, see cmd/csi-mock-proxy.
+	proxyArgs []string
+	// provisionerArgs are added to the external provisioner.
+	provisionerArgs []string
+}
+
+const (
+	mockProvisionTest    = "[Testpattern: Dynamic PV (default fs)] provisioning should provision storage with defaults"
+	mockMountOptionsTest = "[Testpattern: Dynamic PV (default fs)] provisioning should provision storage with mount options"
+	mockSubPathTest      = "[Testpattern: Dynamic PV (default fs)] subPath should support existing directory"
+)
+
+var mockBehaviors = []mockBehavior{
+	{
+		name:        "mock",
+		description: "all calls succeed, tests which need no capabilities pass, all others get skipped",
+		expected: []MockExpectation{
+			{Test: mockProvisionTest, Result: MockPassed},
+			{Test: mockMountOptionsTest, Result: MockSkipped},
+			{Test: mockSubPathTest, Result: MockPassed},
+		},
+	},
+	{
+		name:        "mock-fail-node-publish",
+		description: "NodePublishVolume fails, provisioning works but tests with pods that use a volume fail",
+		expected: []MockExpectation{
+			{Test: mockProvisionTest, Result: MockPassed},
+			{Test: mockMountOptionsTest, Result: MockSkipped},
+			{Test: mockSubPathTest, Result: MockFailed},
+		},
+		proxyArgs: []string{"--error=/csi.v1.Node/NodePublishVolume=Internal"},
+	},
+	{
+		name:        "mock-slow-create-volume",
+		description: fmt.Sprintf("CreateVolume takes %s, tests still pass", mockCreateVolumeDelay),
+		expected: []MockExpectation{
+			{Test: mockProvisionTest, Result: MockPassed, MinDuration: mockCreateVolumeDelay},
+		},
+		proxyArgs: []string{fmt.Sprintf("--delay=/csi.v1.Controller/CreateVolume=%s", mockCreateVolumeDelay)},
+		// Otherwise the provisioner gives up on each call after 10s and
+		// never gets a volume.
+		provisionerArgs: []string{fmt.Sprintf("--timeout=%s", 2*mockCreateVolumeDelay)},
+	},
+	{
+		name:        "mock-no-capabilities",
+		description: "the driver advertises no capabilities, so the external provisioner refuses to provision and tests fail",
+		expected: []MockExpectation{
+			{Test: mockProvisionTest, Result: MockFailed},
+			{Test: mockMountOptionsTest, Result: MockSkipped},
+			{Test: mockSubPathTest, Result: MockFailed},
+		},
+		proxyArgs: []string{
+			"--empty=/csi.v1.Identity/GetPluginCapabilities",
+			"--empty=/csi.v1.Controller/ControllerGetCapabilities",
+			"--empty=/csi.v1.Node/NodeGetCapabilities",
+		},
+	},
+}
+
+// MockExpectations returns the expected test results for each variant
+// of the mock driver, indexed by the name of the variant.
+func MockExpectations() map[string][]MockExpectation {
+	expectations := map[string][]MockExpectation{}
+	for _, behavior := range mockBehaviors {
+		expectations[behavior.name] = behavior.expected
+	}
+	return expectations
+}
+
+func init() {
+	for _, behavior := range mockBehaviors {
+		behavior := behavior
+		registry.Register(behavior.name, func() testsuites.TestDriver {
+			return initMockCSIDriver(behavior)
+		}, registry.Metadata{
+			Description:  "CSI mock driver for testing csi-certify itself: " + behavior.description,
+			ParallelSafe: true,
+			OptIn:        true,
+		})
+	}
+}
+
+// mockCSIDriver deploys the CSI mock driver. It is meant for checking
+// that csi-certify passes, fails and skips tests for the right reasons,
+// not for certifying anything.
+type mockCSIDriver struct {
+	driverInfo testsuites.DriverInfo
+	behavior   mockBehavior
+	manifests  []string
+}
+
+func initMockCSIDriver(behavior mockBehavior) testsuites.TestDriver {
+	return &mockCSIDriver{
+		driverInfo: testsuites.DriverInfo{
+			Name:        "csi-mock",
+			MaxFileSize: testpatterns.FileSizeMedium,
+			SupportedFsType: sets.NewString(
+				"", // Default fsType
+			),
+			// The mock driver does not store data, so it has none
+			// of the capabilities.
+			Capabilities: map[testsuites.Capability]bool{},
+		},
+		behavior: behavior,
+		manifests: []string{
+			"hostpath/attacher-rbac.yaml",
+			"hostpath/driver-registrar-rbac.yaml",
+			"hostpath/provisioner-rbac.yaml",
+			"mock/csi-mock-rbac.yaml",
+			"mock/csi-mock-driver.yaml",
+			"mock/csi-mock-provisioner.yaml",
+		},
+	}
+}
+
+var _ testsuites.TestDriver = &mockCSIDriver{}
+var _ testsuites.DynamicPVTestDriver = &mockCSIDriver{}
+
+func (m *mockCSIDriver) GetDriverInfo() *testsuites.DriverInfo {
+	return &m.driverInfo
+}
+
+func (m *mockCSIDriver) SkipUnsupportedTest(pattern testpatterns.TestPattern) {
+}
+
+func (m *mockCSIDriver) GetDynamicProvisionStorageClass(config *testsuites.PerTestConfig, fsType string) *storagev1.StorageClass {
+	provisioner := config.GetUniqueDriverName()
+	parameters := map[string]string{}
+	ns := config.Framework.Namespace.Name
+	suffix := fmt.Sprintf("%s-sc", provisioner)
+
+	return testsuites.GetStorageClass(provisioner, parameters, nil, ns, suffix)
+}
+
+func (m *mockCSIDriver) GetClaimSize() string {
+	return "1Gi"
+}
+
+func (m *mockCSIDriver) PrepareTest(f *framework.Framework) (*testsuites.PerTestConfig, func()) {
+	By(fmt.Sprintf("deploying %s driver", m.behavior.name))
+	cancel := testsuites.StartPodLogs(f)
+	cs := f.ClientSet
+
+	// The sidecars share the socket of the mock driver through a
+	// host path, so everything must run on the same node.
+	nodes := framework.GetReadySchedulableNodesOrDie(cs)
+	nodeName := nodes.Items[rand.Intn(len(nodes.Items))].Name
+	config := &testsuites.PerTestConfig{
+		Driver:         m,
+		Prefix:         "mock",
+		Framework:      f,
+		ClientNodeName: nodeName,
+	}
+
+	o := utils.PatchCSIOptions{
+		OldDriverName:            m.driverInfo.Name,
+		NewDriverName:            config.GetUniqueDriverName(),
+		DriverContainerName:      "mock",
+		DriverContainerArguments: []string{"--name=" + config.GetUniqueDriverName()},
+		ProvisionerContainerName: "csi-provisioner",
+		NodeName:                 nodeName,
+	}
+	cleanup, err := config.Framework.CreateFromManifests(func(item interface{}) error {
+		if err := utils.PatchCSIDeployment(config.Framework, o, item); err != nil {
+			return err
+		}
+		m.addArgs(item)
+		return testUtils.PatchImages(item)
+	},
+		m.manifests...)
+	if err != nil {
+		framework.Failf("deploying %s driver: %v", m.behavior.name, err)
+	}
+
+	return config, func() {
+		By(fmt.Sprintf("uninstalling %s driver", m.behavior.name))
+		cleanup()
+		cancel()
+	}
+}
+
+// addArgs adds the arguments of the variant to the proxy and the
+// external provisioner.
+func (m *mockCSIDriver) addArgs(item interface{}) {
+	statefulSet, ok := item.(*apps.StatefulSet)
+	if !ok {
+		return
+	}
+	containers := statefulSet.Spec.Template.Spec.Containers
+	for i := range containers {
+		switch containers[i].Name {
+		case "csi-mock-proxy":
+			containers[i].Args = append(containers[i].Args, m.behavior.proxyArgs...)
+		case "csi-provisioner":
+			containers[i].Args = append(containers[i].Args, m.behavior.provisionerArgs...)
+		}
+	}
+}
@@ -2,25 +2,51 @@ package driver
 
 import (
 	"fmt"
+	"path"
+	"strconv"
+
 	. "github.com/onsi/ginkgo"
 	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/kubernetes/test/e2e/framework"
 	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
 	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
 )
 
+func init() {
+	// Not parallel safe because the plugin gets deployed under
+	// its real name for each test, so the node plugins of
+	// concurrent tests would conflict.
+	registry.Register("nfs", InitNFSDriver, registry.Metadata{
+		Description: "NFS CSI plugin with an NFS server pod for each test",
+	})
+}
+
+// nfsExportDir is the directory that the NFS server pod exports.
+const nfsExportDir = "/exports"
+
 type nfsDriver struct {
 	driverInfo testsuites.DriverInfo
 	manifests  []string
+
+	// The NFS server of the current test. Each volume is a
+	// subdirectory of its export.
+	serverPod *v1.Pod
+	serverIP  string
 }
 
 var NFSdriver func() testsuites.TestDriver
 
 type nfsVolume struct {
+	name      string
 	serverIP  string
 	serverPod *v1.Pod
-	f         *framework.Framework
 }
 
 // initNFSDriver returns nfsDriver that implements TestDriver interface
@@ -32,9 +58,15 @@ func initNFSDriver(name string, manifests ...string) testsuites.TestDriver {
 			SupportedFsType: sets.NewString(
 				"", // Default fsType
 			),
+			// The node plugin passes mount options through to
+			// mount.nfs. These ones show up unchanged in
+			// /proc/mounts.
+			SupportedMountOption: sets.NewString("nosuid", "noatime"),
 			Capabilities: map[testsuites.Capability]bool{
-				testsuites.CapPersistence: true,
-				testsuites.CapExec:        true,
+				testsuites.CapPersistence:  true,
+				testsuites.CapExec:         true,
+				testUtils.CapReadWriteMany: true,
+				testUtils.CapReadOnlyMany:  true,
 			},
 		},
 		manifests: manifests,
@@ -54,14 +86,39 @@ func InitNFSDriver() testsuites.TestDriver {
 var _ testsuites.TestDriver = &nfsDriver{}
 var _ testsuites.PreprovisionedVolumeTestDriver = &nfsDriver{}
 var _ testsuites.PreprovisionedPVTestDriver = &nfsDriver{}
+var _ testsuites.DynamicPVTestDriver = &nfsDriver{}
+var _ testUtils.NodePluginTestDriver = &nfsDriver{}
 
 func (n *nfsDriver) GetDriverInfo() *testsuites.DriverInfo {
 	return &n.driverInfo
 }
 
 func (n *nfsDriver) SkipUnsupportedTest(pattern testpatterns.TestPattern) {
-	if pattern.VolType == testpatterns.DynamicPV {
-		framework.Skipf("NFS Driver does not support dynamic provisioning -- skipping")
+}
+
+// GetDynamicProvisionStorageClass returns a StorageClass for the NFS
+// server of the current test. The nfsProvisioner started by
+// PrepareTest creates a subdirectory of the share for each PVC.
+func (n *nfsDriver) GetDynamicProvisionStorageClass(config *testsuites.PerTestConfig, fsType string) *storagev1.StorageClass {
+	provisioner := config.GetUniqueDriverName()
+	parameters := map[string]string{
+		"server": n.serverIP,
+		"share":  "/",
+	}
+	ns := config.Framework.Namespace.Name
+	suffix := fmt.Sprintf("%s-sc", provisioner)
+
+	return testsuites.GetStorageClass(provisioner, parameters, nil, ns, suffix)
+}
+
+func (n *nfsDriver) GetClaimSize() string {
+	return "5Gi"
+}
+
+func (n *nfsDriver) GetNodePlugin(config *testsuites.PerTestConfig) testUtils.PodSelector {
+	return testUtils.PodSelector{
+		Namespace:     config.Framework.Namespace.Name,
+		LabelSelector: "app=csi-nodeplugin-nfsplugin",
 	}
 }
 
@@ -70,11 +127,13 @@ func (n *nfsDriver) GetPersistentVolumeSource(readOnly bool, fsType string, volu
 	return &v1.PersistentVolumeSource{
 		CSI: &v1.CSIPersistentVolumeSource{
 			Driver:       n.driverInfo.Name,
-			VolumeHandle: "nfs-vol",
+			VolumeHandle: nv.serverIP + ":" + nv.share(),
+			ReadOnly:     readOnly,
+			FSType:       fsType,
 			VolumeAttributes: map[string]string{
 				"server":   nv.serverIP,
-				"share":    "/",
-				"readOnly": "true",
+				"share":    nv.share(),
+				"readOnly": strconv.FormatBool(readOnly),
 			},
 		},
 	}, nil
@@ -88,48 +147,63 @@ func (n *nfsDriver) PrepareTest(f *framework.Framework) (*testsuites.PerTestConf
 	}
 
 	//Install the nfs driver from the manifests
-	cleanup, err := config.Framework.CreateFromManifests(nil, n.manifests...)
+	cleanup, err := config.Framework.CreateFromManifests(testUtils.PatchImages, n.manifests...)
 
 	if err != nil {
 		framework.Failf("deploying %s driver: %v", n.driverInfo.Name, err)
 	}
 
+	//Create nfs server pod, shared by all volumes of the test
+	c := framework.VolumeTestConfig{
+		Namespace:          f.Namespace.Name,
+		Prefix:             "nfs",
+		ServerImage:        testUtils.RewriteImage("nfs-server", "gcr.io/kubernetes-e2e-test-images/volume/nfs:1.0"),
+		ServerPorts:        []int{2049},
+		ServerVolumes:      map[string]string{"": nfsExportDir},
+		ServerReadyMessage: "NFS started",
+	}
+	config.ServerConfig = &c
+	n.serverPod, n.serverIP = framework.CreateStorageServer(f.ClientSet, c)
+
+	By(fmt.Sprintf("starting NFS provisioner %s", config.GetUniqueDriverName()))
+	provisioner := newNFSProvisioner(f.ClientSet, config.GetUniqueDriverName(), n.driverInfo.Name, f.Namespace.Name, n.serverPod)
+	provisioner.start()
+
 	return config, func() {
+		By(fmt.Sprintf("stopping NFS provisioner %s", config.GetUniqueDriverName()))
+		provisioner.stop()
+
+		By(fmt.Sprintf("deleting NFS server for %s driver", n.driverInfo.Name))
+		framework.CleanUpVolumeServer(f, n.serverPod)
+		n.serverPod, n.serverIP = nil, ""
+
 		By(fmt.Sprintf("uninstalling %s driver", n.driverInfo.Name))
 		cleanup()
 	}
 }
 
 func (n *nfsDriver) CreateVolume(config *testsuites.PerTestConfig, volType testpatterns.TestVolType) testsuites.TestVolume {
-	f := config.Framework
-	cs := f.ClientSet
-	ns := f.Namespace
-
 	switch volType {
 	case testpatterns.InlineVolume:
 		fallthrough
 	case testpatterns.PreprovisionedPV:
 
-		//Create nfs server pod
-		c := framework.VolumeTestConfig{
-			Namespace:          ns.Name,
-			Prefix:             "nfs",
-			ServerImage:        "gcr.io/kubernetes-e2e-test-images/volume/nfs:1.0",
-			ServerPorts:        []int{2049},
-			ServerVolumes:      map[string]string{"": "/exports"},
-			ServerReadyMessage: "NFS started",
+		//Each volume gets its own, initially empty export subdirectory
+		name := names.SimpleNameGenerator.GenerateName("nfs-vol-")
+		nv := &nfsVolume{
+			name:      name,
+			serverIP:  n.serverIP,
+			serverPod: n.serverPod,
 		}
-		config.ServerConfig = &c
-		serverPod, serverIP := framework.CreateStorageServer(cs, c)
-
-		return &nfsVolume{
-			serverIP:  serverIP,
-			serverPod: serverPod,
-			f:         f,
+		By(fmt.Sprintf("creating NFS export %s", nv.share()))
+		if _, err := utils.PodExec(n.serverPod, "mkdir -m 0777 "+nv.path()); err != nil {
+			framework.Failf("creating directory %s on NFS server: %v", nv.path(), err)
 		}
 
+		return nv
+
 	case testpatterns.DynamicPV:
-		// Do nothing
+		// Do nothing, the provisioner creates the volumes
 	default:
 		framework.Failf("Unsupported volType:%v is specified", volType)
 	}
@@ -137,5 +211,18 @@ func (n *nfsDriver) CreateVolume(config *testsuites.PerTestConfig, volType testp
 }
 
 func (v *nfsVolume) DeleteVolume() {
-	framework.CleanUpVolumeServer(v.f, v.serverPod)
+	By(fmt.Sprintf("deleting NFS export %s", v.share()))
+	if _, err := utils.PodExec(v.serverPod, "rm -rf "+v.path()); err != nil {
+		framework.Failf("deleting directory %s on NFS server: %v", v.path(), err)
+	}
+}
+
+// share is the path of the volume as seen by NFS clients.
+func (v *nfsVolume) share() string {
+	return "/" + v.name
+}
+
+// path is the directory of the volume inside the NFS server pod.
+func (v *nfsVolume) path() string {
+	return path.Join(nfsExportDir, v.name)
 }
@@ -0,0 +1,206 @@
+package driver
+
+import (
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+// nfsProvisionedByAnnotation is set on PVs by the provisioner which
+// created them, like the external provisioner does.
+const nfsProvisionedByAnnotation = "pv.kubernetes.io/provisioned-by"
+
+// nfsProvisioner dynamically provisions volumes for the NFS TestDriver.
+// The NFS plugin image has no CSI controller service that the external
+// provisioner could call, so this runs inside csi-certify for the
+// duration of a test instead. For each pending PVC of a StorageClass
+// with its name as provisioner it creates a subdirectory of the
+// "share" parameter on the NFS server pod and a PV for it that uses
+// the CSI driver with the "server" parameter. Released PVs with the
+// Delete reclaim policy get removed together with their directory.
+type nfsProvisioner struct {
+	cs clientset.Interface
+	// name is the provisioner in the StorageClass.
+	name string
+	// driverName is the CSI driver in the PVs.
+	driverName string
+	// namespace is where the PVCs get created.
+	namespace string
+	serverPod *v1.Pod
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func newNFSProvisioner(cs clientset.Interface, name, driverName, namespace string, serverPod *v1.Pod) *nfsProvisioner {
+	return &nfsProvisioner{
+		cs:         cs,
+		name:       name,
+		driverName: driverName,
+		namespace:  namespace,
+		serverPod:  serverPod,
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// start runs the provisioner in the background until stop is called.
+func (p *nfsProvisioner) start() {
+	go func() {
+		defer close(p.doneCh)
+		wait.Until(p.sync, time.Second, p.stopCh)
+	}()
+}
+
+// stop waits for the provisioner to finish and deletes the PVs it
+// created which are still left.
+func (p *nfsProvisioner) stop() {
+	close(p.stopCh)
+	<-p.doneCh
+
+	volumes, err := p.cs.CoreV1().PersistentVolumes().List(metav1.ListOptions{})
+	if err != nil {
+		framework.Logf("NFS provisioner %s: listing PVs: %v", p.name, err)
+		return
+	}
+	for _, pv := range volumes.Items {
+		if pv.Annotations[nfsProvisionedByAnnotation] != p.name {
+			continue
+		}
+		if err := p.cs.CoreV1().PersistentVolumes().Delete(pv.Name, nil); err != nil && !apierrs.IsNotFound(err) {
+			framework.Logf("NFS provisioner %s: deleting PV %s: %v", p.name, pv.Name, err)
+		}
+	}
+}
+
+// sync provisions volumes for new PVCs and deletes released volumes.
+// Errors get logged and retried in the next sync.
+func (p *nfsProvisioner) sync() {
+	claims, err := p.cs.CoreV1().PersistentVolumeClaims(p.namespace).List(metav1.ListOptions{})
+	if err != nil {
+		framework.Logf("NFS provisioner %s: listing PVCs: %v", p.name, err)
+		return
+	}
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		if claim.Status.Phase != v1.ClaimPending || claim.Spec.VolumeName != "" || claim.Spec.StorageClassName == nil {
+			continue
+		}
+		// NFS shares are file systems, so block volumes never get
+		// provisioned and their PVCs stay pending.
+		if claim.Spec.VolumeMode != nil && *claim.Spec.VolumeMode == v1.PersistentVolumeBlock {
+			continue
+		}
+		class, err := p.cs.StorageV1().StorageClasses().Get(*claim.Spec.StorageClassName, metav1.GetOptions{})
+		if err != nil || class.Provisioner != p.name {
+			continue
+		}
+		if err := p.provision(claim, class); err != nil {
+			framework.Logf("NFS provisioner %s: provisioning PVC %s: %v", p.name, claim.Name, err)
+		}
+	}
+
+	volumes, err := p.cs.CoreV1().PersistentVolumes().List(metav1.ListOptions{})
+	if err != nil {
+		framework.Logf("NFS provisioner %s: listing PVs: %v", p.name, err)
+		return
+	}
+	for i := range volumes.Items {
+		pv := &volumes.Items[i]
+		if pv.Annotations[nfsProvisionedByAnnotation] != p.name ||
+			pv.Status.Phase != v1.VolumeReleased ||
+			pv.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimDelete {
+			continue
+		}
+		if err := p.delete(pv); err != nil {
+			framework.Logf("NFS provisioner %s: deleting PV %s: %v", p.name, pv.Name, err)
+		}
+	}
+}
+
+func (p *nfsProvisioner) provision(claim *v1.PersistentVolumeClaim, class *storagev1.StorageClass) error {
+	server, share := class.Parameters["server"], class.Parameters["share"]
+	if server == "" || share == "" {
+		return errors.Errorf("StorageClass %s must have server and share parameters", class.Name)
+	}
+	name := "pvc-" + string(claim.UID)
+	share = path.Join(share, name)
+	dir := path.Join(nfsExportDir, share)
+	if output, err := utils.PodExec(p.serverPod, "mkdir -p -m 0777 "+dir); err != nil {
+		return errors.Wrapf(err, "creating directory %s on NFS server: %s", dir, output)
+	}
+
+	reclaimPolicy := v1.PersistentVolumeReclaimDelete
+	if class.ReclaimPolicy != nil {
+		reclaimPolicy = *class.ReclaimPolicy
+	}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				nfsProvisionedByAnnotation: p.name,
+			},
+		},
+		Spec: v1.PersistentVolumeSpec{
+			Capacity: v1.ResourceList{
+				v1.ResourceStorage: claim.Spec.Resources.Requests[v1.ResourceStorage],
+			},
+			AccessModes: claim.Spec.AccessModes,
+			ClaimRef: &v1.ObjectReference{
+				Kind:       "PersistentVolumeClaim",
+				APIVersion: "v1",
+				Namespace:  claim.Namespace,
+				Name:       claim.Name,
+				UID:        claim.UID,
+			},
+			PersistentVolumeReclaimPolicy: reclaimPolicy,
+			StorageClassName:              class.Name,
+			MountOptions:                  class.MountOptions,
+			VolumeMode:                    claim.Spec.VolumeMode,
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{
+					Driver:       p.driverName,
+					VolumeHandle: server + ":" + share,
+					VolumeAttributes: map[string]string{
+						"server": server,
+						"share":  share,
+					},
+				},
+			},
+		},
+	}
+	if _, err := p.cs.CoreV1().PersistentVolumes().Create(pv); err != nil && !apierrs.IsAlreadyExists(err) {
+		return errors.Wrap(err, "creating PV")
+	}
+	framework.Logf("NFS provisioner %s: created PV %s for PVC %s", p.name, name, claim.Name)
+	return nil
+}
+
+func (p *nfsProvisioner) delete(pv *v1.PersistentVolume) error {
+	var share string
+	if pv.Spec.CSI != nil {
+		share = pv.Spec.CSI.VolumeAttributes["share"]
+	}
+	// Never remove the whole export.
+	if path.Clean("/"+share) == "/" {
+		return errors.Errorf("PV %s has no share", pv.Name)
+	}
+	dir := path.Join(nfsExportDir, share)
+	if output, err := utils.PodExec(p.serverPod, "rm -rf "+dir); err != nil {
+		return errors.Wrapf(err, "deleting directory %s on NFS server: %s", dir, output)
+	}
+	if err := p.cs.CoreV1().PersistentVolumes().Delete(pv.Name, nil); err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	framework.Logf("NFS provisioner %s: deleted PV %s", p.name, pv.Name)
+	return nil
+}
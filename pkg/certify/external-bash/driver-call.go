@@ -8,28 +8,58 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	customTest "github.com/wongma7/csi-certify/pkg/certify/test"
 	"github.com/wongma7/csi-certify/pkg/certify/utils"
 	"k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	"k8s.io/kubernetes/test/e2e/framework"
 	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
 	"k8s.io/kubernetes/test/e2e/storage/testsuites"
 	"os/exec"
+	"sigs.k8s.io/yaml"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 )
 
 const (
-	getDriverInfo = "getDriverInfo"
-	createVolume  = "createVolume"
+	getDriverInfo  = "getDriverInfo"
+	createVolume   = "createVolume"
+	deployDriver   = "deployDriver"
+	undeployDriver = "undeployDriver"
+	beforeTest     = "beforeTest"
+	afterTest      = "afterTest"
+
+	getStorageClass  = "getStorageClass"
+	getSnapshotClass = "getSnapshotClass"
 )
 
+// lifecycleHooks are the optional bash functions that get invoked by
+// PrepareTest and its cleanup. Each of them is called with the test
+// namespace and the unique driver name as arguments.
+var lifecycleHooks = []string{deployDriver, undeployDriver, beforeTest, afterTest}
+
+// classHooks are the optional bash functions that print the storage
+// or snapshot class for a test. Each of them is called with the fsType
+// (empty for snapshot classes) and the test namespace as arguments.
+var classHooks = []string{getStorageClass, getSnapshotClass}
+
 var RunCustomTestDriver = true
 var scriptName = ""
 
+// defaultHookTimeout limits how long a bash function may run unless
+// the driver definition overrides it in HookTimeouts.
+var defaultHookTimeout = 5 * time.Minute
+
 type BashDriverParameter struct {
 }
 
@@ -41,6 +71,7 @@ var bashDriverParam BashDriverParameter
 
 func init() {
 	flag.Var(&bashDriverParam, "bash-testdriver", "name of bashscript that implements all the required testdriver functions")
+	flag.DurationVar(&defaultHookTimeout, "bash-testdriver-timeout", defaultHookTimeout, "maximum runtime of a bash testdriver function, can be overridden per function with HookTimeouts in the driver definition")
 }
 
 func (b BashDriverParameter) String() string {
@@ -67,7 +98,7 @@ func (b BashDriverParameter) Set(filename string) error {
 
 	description := "External Storage " + testsuites.GetDriverNameWithFeatureTags(driver)
 	Describe(description, func() {
-		testsuites.DefineTestSuite(driver, utils.CSITestSuites)
+		customTest.DefineTestSuites(driver)
 	})
 
 	return nil
@@ -78,11 +109,21 @@ func (b BashDriverParameter) getDriverDefinition(filename string) (*bashDriver,
 		return nil, errors.New("missing file name")
 	}
 
-	err, data := execCommand(scriptName, getDriverInfo, "")
+	err, data := execCommand(scriptName, getDriverInfo, "", defaultHookTimeout)
 	if err != nil {
 		return nil, err
 	}
 
+	driver, err := newBashDriver(data, checkBashFuncExists)
+	if err != nil {
+		return nil, errors.Wrap(err, filename)
+	}
+	return driver, nil
+}
+
+// newBashDriver decodes the output of getDriverInfo. funcExists
+// determines which optional bash functions the script defines.
+func newBashDriver(data []byte, funcExists func(string) bool) (*bashDriver, error) {
 	// Some reasonable defaults follow.
 	driver := &bashDriver{
 		utils.DriverDefinition{
@@ -93,17 +134,40 @@ func (b BashDriverParameter) getDriverDefinition(filename string) (*bashDriver,
 			},
 			ClaimSize: "5Gi",
 		},
+		nil,
 		false,
 		false,
+		sets.NewString(),
 	}
 
-	driver.preprovisionedVolumeTestDriver = checkBashFuncExists("createVolume") && checkBashFuncExists("deleteVolume")
+	for _, hook := range append(lifecycleHooks, classHooks...) {
+		if funcExists(hook) {
+			driver.hooks.Insert(hook)
+		}
+	}
+
+	driver.preprovisionedVolumeTestDriver = funcExists("createVolume") && funcExists("deleteVolume")
 	driver.preprovisionedPVTestDriver = driver.preprovisionedVolumeTestDriver
 
 	// TODO: strict checking of the file content once https://github.com/kubernetes/kubernetes/pull/71589
 	// or something similar is merged.
 	if err := runtime.DecodeInto(legacyscheme.Codecs.UniversalDecoder(), data, driver); err != nil {
-		return nil, errors.Wrap(err, filename)
+		return nil, errors.Wrapf(err, "decoding output of %s:\n%s", getDriverInfo, data)
+	}
+
+	// A typo would silently leave the default timeout in place.
+	known := sets.NewString(createVolume)
+	known.Insert(lifecycleHooks...)
+	known.Insert(classHooks...)
+	var unknown []string
+	for hook := range driver.HookTimeouts {
+		if !known.Has(hook) {
+			unknown = append(unknown, hook)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, errors.Errorf("HookTimeouts: unknown bash functions %s, must be one of %s", strings.Join(unknown, ", "), strings.Join(known.List(), ", "))
 	}
 
 	return driver, nil
@@ -130,8 +194,19 @@ var _ runtime.Object = &bashDriver{}
 
 type bashDriver struct {
 	utils.DriverDefinition
+
+	// HookTimeouts overrides --bash-testdriver-timeout for
+	// individual bash functions, for example:
+	//
+	//   HookTimeouts:
+	//     deployDriver: 10m
+	HookTimeouts map[string]metav1.Duration
+
 	preprovisionedVolumeTestDriver bool
 	preprovisionedPVTestDriver     bool
+	// hooks contains the optional lifecycle and class hooks defined
+	// by the script.
+	hooks sets.String
 }
 
 func (b *bashDriver) GetDriverInfo() *testsuites.DriverInfo {
@@ -143,7 +218,7 @@ func (b bashDriver) SkipUnsupportedTest(pattern testpatterns.TestPattern) {
 	// TODO (?): add support for more volume types
 	switch pattern.VolType {
 	case testpatterns.DynamicPV:
-		if b.StorageClass.FromName || b.StorageClass.FromFile != "" {
+		if b.StorageClass.FromName || b.StorageClass.FromFile != "" || b.hooks.Has(getStorageClass) {
 			supported = true
 		}
 	case testpatterns.PreprovisionedPV:
@@ -160,7 +235,7 @@ func (b bashDriver) SkipUnsupportedTest(pattern testpatterns.TestPattern) {
 	case "":
 		supported = true
 	case testpatterns.DynamicCreatedSnapshot:
-		if b.SnapshotClass.FromName {
+		if b.SnapshotClass.FromName || b.hooks.Has(getSnapshotClass) {
 			supported = true
 		}
 	}
@@ -173,17 +248,16 @@ func (b bashDriver) CreateVolume(config *testsuites.PerTestConfig, volumeType te
 	f := config.Framework
 	ns := f.Namespace
 
-	cvErr, createVolOutput := execCommand(scriptName, createVolume, ns.Name)
+	cvErr, createVolOutput := execCommand(scriptName, createVolume, ns.Name, b.hookTimeout(createVolume))
 
 	if cvErr != nil {
-		fmt.Printf("Unable to create volume\n")
-		framework.Failf(cvErr.Error())
+		framework.Failf("Unable to create volume: %v", cvErr)
 	}
 	response := make(map[string]string)
 	err := json.Unmarshal([]byte(createVolOutput), &response)
 
 	if err != nil {
-		panic(err)
+		framework.Failf("%s: output is not a JSON object with string values: %v\nOutput:\n%s", createVolume, err, createVolOutput)
 	}
 
 	return &testVolume{
@@ -199,6 +273,7 @@ func (b *bashDriver) GetPersistentVolumeSource(readOnly bool, fsType string, vol
 		CSI: &v1.CSIPersistentVolumeSource{
 			Driver:           b.DriverInfo.Name,
 			VolumeHandle:     volHandle,
+			ReadOnly:         readOnly,
 			VolumeAttributes: tv.volumeAttrib,
 		},
 	}, nil
@@ -207,6 +282,10 @@ func (b *bashDriver) GetPersistentVolumeSource(readOnly bool, fsType string, vol
 func (b bashDriver) GetDynamicProvisionStorageClass(config *testsuites.PerTestConfig, fsType string) *storagev1.StorageClass {
 	f := config.Framework
 
+	if b.hooks.Has(getStorageClass) {
+		return b.getStorageClassFromHook(f.Namespace.Name, fsType)
+	}
+
 	if b.StorageClass.FromName {
 		provisioner := b.DriverInfo.Name
 		parameters := map[string]string{}
@@ -228,6 +307,9 @@ func (b bashDriver) GetDynamicProvisionStorageClass(config *testsuites.PerTestCo
 
 	sc, ok := items[0].(*storagev1.StorageClass)
 	Expect(ok).To(BeTrue(), "storage class from %s", b.StorageClass.FromFile)
+	// Ensure that we can load more than once as required for
+	// GetDynamicProvisionStorageClass by adding a random suffix.
+	sc.Name = names.SimpleNameGenerator.GenerateName(sc.Name + "-")
 	if fsType != "" {
 		if sc.Parameters == nil {
 			sc.Parameters = map[string]string{}
@@ -237,7 +319,39 @@ func (b bashDriver) GetDynamicProvisionStorageClass(config *testsuites.PerTestCo
 	return sc
 }
 
+// getStorageClassFromHook invokes getStorageClass and validates the
+// StorageClass that it printed.
+func (b bashDriver) getStorageClassFromHook(namespace string, fsType string) *storagev1.StorageClass {
+	err, data := execCommand(scriptName, getStorageClass, namespace, b.hookTimeout(getStorageClass), fsType, namespace)
+	if err != nil {
+		framework.Failf("Unable to get storage class: %v", err)
+	}
+
+	obj, err := runtime.Decode(scheme.Codecs.UniversalDeserializer(), data)
+	if err != nil {
+		framework.Failf("%s: output is not a valid object: %v\nOutput:\n%s", getStorageClass, err, data)
+	}
+	sc, ok := obj.(*storagev1.StorageClass)
+	if !ok {
+		framework.Failf("%s: expected a StorageClass, got %T\nOutput:\n%s", getStorageClass, obj, data)
+	}
+	if sc.Provisioner == "" {
+		framework.Failf("%s: provisioner not set\nOutput:\n%s", getStorageClass, data)
+	}
+	if sc.Name == "" {
+		sc.Name = namespace + "-" + sc.Provisioner + "-sc"
+	}
+	// Ensure that we can load more than once as required for
+	// GetDynamicProvisionStorageClass by adding a random suffix.
+	sc.Name = names.SimpleNameGenerator.GenerateName(sc.Name + "-")
+	return sc
+}
+
 func (b bashDriver) GetSnapshotClass(config *testsuites.PerTestConfig) *unstructured.Unstructured {
+	if b.hooks.Has(getSnapshotClass) {
+		return b.getSnapshotClassFromHook(config.Framework.Namespace.Name)
+	}
+
 	if !b.SnapshotClass.FromName {
 		framework.Skipf("Driver %q does not support snapshotting - skipping", b.DriverInfo.Name)
 	}
@@ -250,6 +364,31 @@ func (b bashDriver) GetSnapshotClass(config *testsuites.PerTestConfig) *unstruct
 	return testsuites.GetSnapshotClass(snapshotter, parameters, ns, suffix)
 }
 
+// getSnapshotClassFromHook invokes getSnapshotClass and validates the
+// VolumeSnapshotClass that it printed.
+func (b bashDriver) getSnapshotClassFromHook(namespace string) *unstructured.Unstructured {
+	err, data := execCommand(scriptName, getSnapshotClass, namespace, b.hookTimeout(getSnapshotClass), "", namespace)
+	if err != nil {
+		framework.Failf("Unable to get snapshot class: %v", err)
+	}
+
+	snapshotClass := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(data, &snapshotClass.Object); err != nil {
+		framework.Failf("%s: output is not a valid object: %v\nOutput:\n%s", getSnapshotClass, err, data)
+	}
+	if snapshotClass.GetKind() != "VolumeSnapshotClass" {
+		framework.Failf("%s: expected a VolumeSnapshotClass, got kind %q\nOutput:\n%s", getSnapshotClass, snapshotClass.GetKind(), data)
+	}
+	if snapshotter, _, _ := unstructured.NestedString(snapshotClass.Object, "snapshotter"); snapshotter == "" {
+		framework.Failf("%s: snapshotter not set\nOutput:\n%s", getSnapshotClass, data)
+	}
+	if snapshotClass.GetName() == "" {
+		snapshotClass.SetName(namespace + "-" + b.DriverInfo.Name + "-vsc")
+	}
+	snapshotClass.SetName(names.SimpleNameGenerator.GenerateName(snapshotClass.GetName() + "-"))
+	return snapshotClass
+}
+
 func (b *bashDriver) GetClaimSize() string {
 	return b.ClaimSize
 }
@@ -261,58 +400,209 @@ func (b *bashDriver) PrepareTest(f *framework.Framework) (*testsuites.PerTestCon
 		Framework:      f,
 		ClientNodeName: b.ClientNodeName,
 	}
-	return config, func() {}
+	ns := f.Namespace.Name
+	driverName := config.GetUniqueDriverName()
+
+	// Hooks that have been started and whose counterpart therefore
+	// has to be invoked during cleanup, even when they failed half-way.
+	var deployed, prepared bool
+	var cleanupHandle framework.CleanupActionHandle
+	cleanup := func() {
+		if cleanupHandle == nil {
+			// Already done.
+			return
+		}
+		framework.RemoveCleanupAction(cleanupHandle)
+		cleanupHandle = nil
+
+		var errs []error
+		if prepared {
+			errs = append(errs, b.runHook(afterTest, ns, driverName))
+		}
+		if deployed {
+			By(fmt.Sprintf("uninstalling %s driver", b.DriverInfo.Name))
+			errs = append(errs, b.runHook(undeployDriver, ns, driverName))
+		}
+		if err := utilerrors.NewAggregate(errs); err != nil {
+			framework.Failf("cleaning up after test: %v", err)
+		}
+	}
+	// Ensures that the hooks also run when the test gets aborted
+	// before it had a chance to call cleanup itself.
+	cleanupHandle = framework.AddCleanupAction(cleanup)
+
+	if b.hooks.Has(deployDriver) {
+		By(fmt.Sprintf("deploying %s driver", b.DriverInfo.Name))
+		deployed = true
+		if err := b.runHook(deployDriver, ns, driverName); err != nil {
+			cleanup()
+			framework.Failf("deploying %s driver: %v", b.DriverInfo.Name, err)
+		}
+	}
+	if b.hooks.Has(beforeTest) {
+		prepared = true
+		if err := b.runHook(beforeTest, ns, driverName); err != nil {
+			cleanup()
+			framework.Failf("preparing test for %s driver: %v", b.DriverInfo.Name, err)
+		}
+	}
+
+	return config, cleanup
+}
+
+// runHook invokes one of the optional lifecycle hooks, if the script
+// defines it, and copies its output into the test log.
+func (b *bashDriver) runHook(hook string, namespace string, driverName string) error {
+	if !b.hooks.Has(hook) {
+		return nil
+	}
+	err, output := execCommand(scriptName, hook, namespace, b.hookTimeout(hook), namespace, driverName)
+	if err != nil {
+		return err
+	}
+	framework.Logf("%s output:\n%s", hook, string(output))
+	return nil
 }
 
-//Example call: getCommand("nfs", createVolume)
-func execCommand(pluginName string, cmdName string, namespaceToUse string) (error, []byte) {
-	currentNameSpace, getNameSpaceErr := getCurrentNameSpace()
+// hookTimeout returns how long the given bash function may run.
+func (b *bashDriver) hookTimeout(hook string) time.Duration {
+	if timeout, ok := b.HookTimeouts[hook]; ok {
+		return timeout.Duration
+	}
+	return defaultHookTimeout
+}
+
+// hookError is returned by execCommand when a bash function fails or
+// runs for too long. Its message includes everything that the function
+// printed, so that the output ends up in the Ginkgo failure.
+type hookError struct {
+	hook     string
+	err      error
+	timedOut bool
+	timeout  time.Duration
+	stdout   []byte
+	stderr   []byte
+}
+
+func (e *hookError) Error() string {
+	var msg string
+	if e.timedOut {
+		msg = fmt.Sprintf("%s: killed after timeout of %s", e.hook, e.timeout)
+	} else {
+		msg = fmt.Sprintf("%s: %v", e.hook, e.err)
+	}
+	if len(e.stdout) > 0 {
+		msg += fmt.Sprintf("\nStdout:\n%s", e.stdout)
+	}
+	if len(e.stderr) > 0 {
+		msg += fmt.Sprintf("\nStderr:\n%s", e.stderr)
+	}
+	return msg
+}
+
+// Example call: execCommand("nfs", createVolume, "", time.Minute)
+//
+// Additional arguments are passed on to the bash function. The function
+// and everything it started get killed when it runs longer than timeout.
+// The kubectl calls which switch the namespace for the function count
+// against the same timeout, so a hanging API server cannot block the
+// test either.
+func execCommand(pluginName string, cmdName string, namespaceToUse string, timeout time.Duration, args ...string) (error, []byte) {
+	deadline := time.Now().Add(timeout)
+	currentNameSpace, getNameSpaceErr := getCurrentNameSpace(deadline)
 	if getNameSpaceErr != nil {
-		return getNameSpaceErr, nil
+		return errors.Wrapf(getNameSpaceErr, "%s: getting current namespace", cmdName), nil
 	}
 
 	if namespaceToUse == "" {
 		namespaceToUse = currentNameSpace
 	}
 
-	namespaceErr := setCurrentNameSpace(namespaceToUse)
+	namespaceErr := setCurrentNameSpace(namespaceToUse, deadline)
 	if namespaceErr != nil {
-		return namespaceErr, nil
+		return errors.Wrapf(namespaceErr, "%s: setting namespace", cmdName), nil
 	}
 
-	fmt.Printf("Command = %s", ". ../../pkg/certify/external-bash/"+pluginName+" && "+cmdName)
+	command := ". ../../pkg/certify/external-bash/" + pluginName + " && " + cmdName
+	for _, arg := range args {
+		command += " " + shellQuote(arg)
+	}
+	fmt.Printf("Command = %s\n", command)
 
-	cmd := exec.Command("bash", "-c", ". ../../pkg/certify/external-bash/"+pluginName+" && "+cmdName)
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	err := cmd.Run()
+	cmd := exec.Command("bash", "-c", command)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	timedOut, err := runUntil(cmd, deadline)
 
-	namespaceErr = setCurrentNameSpace(currentNameSpace)
+	namespaceErr = setCurrentNameSpace(currentNameSpace, deadline)
 	if namespaceErr != nil {
-		return namespaceErr, nil
+		return errors.Wrapf(namespaceErr, "%s: restoring namespace", cmdName), nil
 	}
 
-	if err != nil {
+	if err != nil || timedOut {
 		fmt.Printf("Unable to execute command: %s \n", cmdName)
-		return err, nil
+		return &hookError{
+			hook:     cmdName,
+			err:      err,
+			timedOut: timedOut,
+			timeout:  timeout,
+			stdout:   stdout.Bytes(),
+			stderr:   stderr.Bytes(),
+		}, nil
 	}
 
-	fmt.Printf("Executing command %s \n %s", cmdName, out.String())
-	return nil, out.Bytes()
+	fmt.Printf("Executing command %s \n %s", cmdName, stdout.String())
+	if stderr.Len() > 0 {
+		fmt.Printf("Stderr of command %s \n %s", cmdName, stderr.String())
+	}
+	return nil, stdout.Bytes()
+
+}
+
+// runUntil runs cmd in a separate process group and kills the group
+// when cmd is still running at the deadline, which also kills
+// processes started by it, like a hanging kubectl.
+func runUntil(cmd *exec.Cmd, deadline time.Time) (timedOut bool, err error) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		return false, err
+	}
+	timer := time.AfterFunc(time.Until(deadline), func() {
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	})
+	err = cmd.Wait()
+	return !timer.Stop(), err
+}
+
+// kubectl runs a kubectl command line with bash and returns its
+// combined output. It gets killed at the deadline.
+func kubectl(command string, deadline time.Time) ([]byte, error) {
+	cmd := exec.Command("bash", "-c", command)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	timedOut, err := runUntil(cmd, deadline)
+	if timedOut {
+		return output.Bytes(), errors.Errorf("%s: killed after timeout", command)
+	}
+	return output.Bytes(), err
+}
 
+// shellQuote turns s into a single word for bash.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
 }
 
-func setCurrentNameSpace(namespace string) error {
-	currentContextCmd := exec.Command("bash", "-c", "kubectl config current-context")
-	currentContextOutput, err := currentContextCmd.CombinedOutput()
+func setCurrentNameSpace(namespace string, deadline time.Time) error {
+	currentContextOutput, err := kubectl("kubectl config current-context", deadline)
 
 	if err != nil {
 		fmt.Printf("Unable to get current context")
 		return err
 	}
 
-	cmd := exec.Command("bash", "-c", "kubectl config set-context "+strings.TrimSpace(string(currentContextOutput))+" --namespace="+namespace+"")
-	output, err := cmd.CombinedOutput()
+	output, err := kubectl("kubectl config set-context "+strings.TrimSpace(string(currentContextOutput))+" --namespace="+namespace+"", deadline)
 	fmt.Printf("Kubectl output: %s\n\n", string(output))
 
 	if err != nil {
@@ -324,9 +614,8 @@ func setCurrentNameSpace(namespace string) error {
 
 }
 
-func getCurrentNameSpace() (string, error) {
-	getNameSpaceCmd := exec.Command("bash", "-c", "kubectl config view | grep namespace |  cut -d':' -f 2")
-	getNameSpaceOutput, err := getNameSpaceCmd.CombinedOutput()
+func getCurrentNameSpace(deadline time.Time) (string, error) {
+	getNameSpaceOutput, err := kubectl("kubectl config view | grep namespace |  cut -d':' -f 2", deadline)
 
 	if err != nil {
 		return "", err
@@ -342,7 +631,7 @@ func checkBashFuncExists(bashFunc string) bool {
 
 	if err != nil {
 		fmt.Printf("Unable to verify bash function: %s exists", bashFunc)
-		fmt.Printf(err.Error())
+		fmt.Printf("%v", err)
 	}
 
 	if strings.TrimSpace(string(checkCmdOutput)) == "found" {
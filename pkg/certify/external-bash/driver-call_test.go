@@ -0,0 +1,80 @@
+package externalBash
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wongma7/csi-certify/pkg/certify/fake"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestSkipUnsupportedTest(t *testing.T) {
+	// Hooks which print classes would need the script, therefore
+	// only the functions which merely enable tests are defined here.
+	for filename, funcs := range map[string]sets.String{
+		"hostpath-driver-info.yaml": sets.NewString(),
+		"nfs-driver-info.yaml":      sets.NewString(createVolume, "deleteVolume"),
+	} {
+		t.Run(filename, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			driver, err := newBashDriver(data, funcs.Has)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := strings.TrimSuffix(filename, ".yaml") + ".golden"
+			fake.CheckGolden(t, filepath.Join("testdata", golden), fake.Report(driver))
+		})
+	}
+}
+
+func TestHookTimeouts(t *testing.T) {
+	for _, test := range []struct {
+		name, timeouts, err string
+	}{
+		{name: "known", timeouts: "deployDriver: 10m\n  createVolume: 30s"},
+		{name: "unknown", timeouts: "deployDriver: 10m\n  deploy: 10m\n  getDriverInfo: 1m",
+			err: "HookTimeouts: unknown bash functions deploy, getDriverInfo, must be one of afterTest, beforeTest, createVolume, deployDriver, getSnapshotClass, getStorageClass, undeployDriver"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data := "DriverInfo:\n  Name: example\nHookTimeouts:\n  " + test.timeouts + "\n"
+			_, err := newBashDriver([]byte(data), func(string) bool { return false })
+			switch {
+			case test.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+				t.Fatalf("expected error %q, got: %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestExecCommandKubectlTimeout(t *testing.T) {
+	// A kubectl that never finishes, like one talking to an API
+	// server that does not respond.
+	dir, err := ioutil.TempDir("", "fake-kubectl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "kubectl"), []byte("#!/bin/sh\nsleep 60\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	start := time.Now()
+	err, _ = execCommand("nfs", createVolume, "default", 200*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "killed after timeout") {
+		t.Errorf("expected timeout error, got: %v", err)
+	}
+	if duration := time.Since(start); duration > 10*time.Second {
+		t.Errorf("expected kubectl to be killed after the timeout, took %v", duration)
+	}
+}
@@ -0,0 +1,455 @@
+package externalGRPC
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/pkg/errors"
+	"github.com/wongma7/csi-certify/pkg/certify/external-grpc/testdriver"
+	customTest "github.com/wongma7/csi-certify/pkg/certify/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"sigs.k8s.io/yaml"
+)
+
+var RunCustomTestDriver = true
+
+// callTimeout limits how long a single call to the service may take.
+var callTimeout = 5 * time.Minute
+
+type GRPCDriverParameter struct {
+}
+
+var grpcDriverParam GRPCDriverParameter
+
+// connectedDrivers are the TestDrivers created for --grpc-testdriver.
+// Their connections stay open until Close gets called.
+var connectedDrivers []*grpcDriver
+
+func init() {
+	flag.Var(&grpcDriverParam, "grpc-testdriver", "address of a gRPC TestDriver service, either unix:///path/to/socket or host:port")
+	flag.DurationVar(&callTimeout, "grpc-testdriver-timeout", callTimeout, "maximum duration of a single call to the gRPC TestDriver service")
+}
+
+func (g GRPCDriverParameter) String() string {
+	return "<address of gRPC testdriver>"
+}
+
+func (g GRPCDriverParameter) Set(address string) error {
+	RunCustomTestDriver = false
+	driver, err := Connect(address)
+	if err != nil {
+		return err
+	}
+	connectedDrivers = append(connectedDrivers, driver.(*grpcDriver))
+
+	description := "External Storage " + testsuites.GetDriverNameWithFeatureTags(driver)
+	Describe(description, func() {
+		customTest.DefineTestSuites(driver)
+	})
+
+	return nil
+}
+
+// Close closes the connections of the TestDrivers created for
+// --grpc-testdriver. It must be called after all tests ran.
+func Close() error {
+	var errs []error
+	for _, driver := range connectedDrivers {
+		if err := driver.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	connectedDrivers = nil
+	return utilerrors.NewAggregate(errs)
+}
+
+// Connect establishes the connection to the service at the given
+// address and retrieves the static driver information from it. The
+// TestDriver implements io.Closer, which closes the connection.
+func Connect(address string) (testsuites.TestDriver, error) {
+	network, target := "tcp", address
+	if strings.HasPrefix(address, "unix://") {
+		network, target = "unix", strings.TrimPrefix(address, "unix://")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, target,
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout(network, addr, timeout)
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connecting to %s", address)
+	}
+
+	client := testdriver.NewTestDriverClient(conn)
+	info, err := client.GetDriverInfo(ctx, &testdriver.GetDriverInfoRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "%s: GetDriverInfo", address)
+	}
+	if info.Name == "" {
+		conn.Close()
+		return nil, errors.Errorf("%s: GetDriverInfo: name not set", address)
+	}
+
+	// Same defaults as for a DriverDefinition.
+	driver := &grpcDriver{
+		conn:   conn,
+		client: client,
+		driverInfo: testsuites.DriverInfo{
+			Name:                 info.Name,
+			FeatureTag:           info.FeatureTag,
+			MaxFileSize:          info.MaxFileSize,
+			SupportedFsType:      sets.NewString(info.SupportedFsTypes...),
+			SupportedMountOption: sets.NewString(info.SupportedMountOptions...),
+			RequiredMountOption:  sets.NewString(info.RequiredMountOptions...),
+			Capabilities:         map[testsuites.Capability]bool{},
+		},
+		claimSize:   info.ClaimSize,
+		volumeTypes: sets.NewString(info.VolumeTypes...),
+		snapshots:   info.DynamicSnapshots,
+		testIDs:     map[*framework.Framework]string{},
+	}
+	if driver.driverInfo.SupportedFsType.Len() == 0 {
+		driver.driverInfo.SupportedFsType.Insert("") // Default fsType
+	}
+	if driver.claimSize == "" {
+		driver.claimSize = "5Gi"
+	}
+	for _, capability := range info.Capabilities {
+		driver.driverInfo.Capabilities[testsuites.Capability(capability)] = true
+	}
+
+	return driver, nil
+}
+
+var _ testsuites.TestDriver = &grpcDriver{}
+var _ io.Closer = &grpcDriver{}
+
+// We have to implement all interfaces because it depends on the
+// service which of them are really supported.
+// grpcDriver.SkipUnsupportedTest checks that based on the information
+// returned by GetDriverInfo.
+var _ testsuites.DynamicPVTestDriver = &grpcDriver{}
+var _ testsuites.SnapshottableTestDriver = &grpcDriver{}
+var _ testsuites.PreprovisionedVolumeTestDriver = &grpcDriver{}
+var _ testsuites.PreprovisionedPVTestDriver = &grpcDriver{}
+var _ testsuites.InlineVolumeTestDriver = &grpcDriver{}
+
+// grpcDriver implements the TestDriver interfaces by forwarding each
+// call to a TestDriver service.
+type grpcDriver struct {
+	conn        *grpc.ClientConn
+	client      testdriver.TestDriverClient
+	driverInfo  testsuites.DriverInfo
+	claimSize   string
+	volumeTypes sets.String
+	snapshots   bool
+
+	// testIDs contains the ID that the service assigned to each
+	// test that is currently running.
+	testIDs map[*framework.Framework]string
+}
+
+type grpcVolume struct {
+	driver   *grpcDriver
+	testID   string
+	volumeID string
+}
+
+// Close closes the connection to the service.
+func (g *grpcDriver) Close() error {
+	return g.conn.Close()
+}
+
+func (g *grpcDriver) GetDriverInfo() *testsuites.DriverInfo {
+	return &g.driverInfo
+}
+
+func (g *grpcDriver) SkipUnsupportedTest(pattern testpatterns.TestPattern) {
+	if pattern.SnapshotType != "" {
+		if !g.snapshots {
+			framework.Skipf("Driver %q does not support snapshot type %q - skipping", g.driverInfo.Name, pattern.SnapshotType)
+		}
+	} else if !g.volumeTypes.Has(string(pattern.VolType)) {
+		framework.Skipf("Driver %q does not support volume type %q - skipping", g.driverInfo.Name, pattern.VolType)
+	}
+
+	var response *testdriver.SkipUnsupportedTestResponse
+	err := g.call("SkipUnsupportedTest", func(ctx context.Context) (err error) {
+		response, err = g.client.SkipUnsupportedTest(ctx, &testdriver.SkipUnsupportedTestRequest{
+			Pattern: &testdriver.TestPattern{
+				Name:         pattern.Name,
+				FeatureTag:   pattern.FeatureTag,
+				VolType:      string(pattern.VolType),
+				FsType:       pattern.FsType,
+				VolMode:      string(pattern.VolMode),
+				SnapshotType: string(pattern.SnapshotType),
+			},
+		})
+		return
+	})
+	if status.Code(errors.Cause(err)) == codes.Unimplemented {
+		// Optional.
+		return
+	}
+	framework.ExpectNoError(err)
+	if response.SkipReason != "" {
+		framework.Skipf("Driver %q does not support test pattern %q: %s - skipping", g.driverInfo.Name, pattern.Name, response.SkipReason)
+	}
+}
+
+func (g *grpcDriver) PrepareTest(f *framework.Framework) (*testsuites.PerTestConfig, func()) {
+	config := &testsuites.PerTestConfig{
+		Driver:    g,
+		Prefix:    "external",
+		Framework: f,
+	}
+
+	cancelLogs := testsuites.StartPodLogs(f)
+	var response *testdriver.PrepareTestResponse
+	err := g.call("PrepareTest", func(ctx context.Context) (err error) {
+		response, err = g.client.PrepareTest(ctx, &testdriver.PrepareTestRequest{
+			Namespace:        f.Namespace.Name,
+			UniqueDriverName: config.GetUniqueDriverName(),
+			TestName:         CurrentGinkgoTestDescription().FullTestText,
+		})
+		return
+	})
+	if err != nil {
+		cancelLogs()
+		framework.Failf("preparing test for %s driver: %v", g.driverInfo.Name, err)
+	}
+	if response.TestId == "" {
+		cancelLogs()
+		framework.Failf("preparing test for %s driver: PrepareTest returned no test_id", g.driverInfo.Name)
+	}
+	g.testIDs[f] = response.TestId
+	config.ClientNodeName = response.ClientNodeName
+
+	return config, func() {
+		defer cancelLogs()
+		delete(g.testIDs, f)
+		err := g.call("CleanupTest", func(ctx context.Context) error {
+			_, err := g.client.CleanupTest(ctx, &testdriver.CleanupTestRequest{
+				TestId: response.TestId,
+			})
+			return err
+		})
+		framework.ExpectNoError(err, "cleaning up after test")
+	}
+}
+
+func (g *grpcDriver) CreateVolume(config *testsuites.PerTestConfig, volType testpatterns.TestVolType) testsuites.TestVolume {
+	testID := g.testID(config)
+	var response *testdriver.CreateVolumeResponse
+	err := g.call("CreateVolume", func(ctx context.Context) (err error) {
+		response, err = g.client.CreateVolume(ctx, &testdriver.CreateVolumeRequest{
+			TestId:  testID,
+			VolType: string(volType),
+		})
+		return
+	})
+	g.skipIfUnimplemented(err)
+	framework.ExpectNoError(err)
+	if response.VolumeId == "" {
+		framework.Failf("CreateVolume returned no volume_id")
+	}
+
+	return &grpcVolume{
+		driver:   g,
+		testID:   testID,
+		volumeID: response.VolumeId,
+	}
+}
+
+func (v *grpcVolume) DeleteVolume() {
+	err := v.driver.call("DeleteVolume", func(ctx context.Context) error {
+		_, err := v.driver.client.DeleteVolume(ctx, &testdriver.DeleteVolumeRequest{
+			TestId:   v.testID,
+			VolumeId: v.volumeID,
+		})
+		return err
+	})
+	framework.ExpectNoError(err)
+}
+
+func (g *grpcDriver) GetPersistentVolumeSource(readOnly bool, fsType string, volume testsuites.TestVolume) (*v1.PersistentVolumeSource, *v1.VolumeNodeAffinity) {
+	gv := g.volume("GetPersistentVolumeSource", volume)
+	var response *testdriver.GetPersistentVolumeSourceResponse
+	err := g.call("GetPersistentVolumeSource", func(ctx context.Context) (err error) {
+		response, err = g.client.GetPersistentVolumeSource(ctx, &testdriver.GetPersistentVolumeSourceRequest{
+			TestId:   gv.testID,
+			VolumeId: gv.volumeID,
+			ReadOnly: readOnly,
+			FsType:   fsType,
+		})
+		return
+	})
+	g.skipIfUnimplemented(err)
+	framework.ExpectNoError(err)
+
+	var pvSource *v1.PersistentVolumeSource
+	if len(response.PersistentVolumeSource) > 0 {
+		pvSource = &v1.PersistentVolumeSource{}
+		decode("GetPersistentVolumeSource", response.PersistentVolumeSource, pvSource)
+	}
+	var nodeAffinity *v1.VolumeNodeAffinity
+	if len(response.VolumeNodeAffinity) > 0 {
+		nodeAffinity = &v1.VolumeNodeAffinity{}
+		decode("GetPersistentVolumeSource", response.VolumeNodeAffinity, nodeAffinity)
+	}
+	return pvSource, nodeAffinity
+}
+
+func (g *grpcDriver) GetVolumeSource(readOnly bool, fsType string, volume testsuites.TestVolume) *v1.VolumeSource {
+	gv := g.volume("GetVolumeSource", volume)
+	var response *testdriver.GetVolumeSourceResponse
+	err := g.call("GetVolumeSource", func(ctx context.Context) (err error) {
+		response, err = g.client.GetVolumeSource(ctx, &testdriver.GetVolumeSourceRequest{
+			TestId:   gv.testID,
+			VolumeId: gv.volumeID,
+			ReadOnly: readOnly,
+			FsType:   fsType,
+		})
+		return
+	})
+	g.skipIfUnimplemented(err)
+	framework.ExpectNoError(err)
+
+	if len(response.VolumeSource) == 0 {
+		return nil
+	}
+	volSource := &v1.VolumeSource{}
+	decode("GetVolumeSource", response.VolumeSource, volSource)
+	return volSource
+}
+
+func (g *grpcDriver) GetDynamicProvisionStorageClass(config *testsuites.PerTestConfig, fsType string) *storagev1.StorageClass {
+	var response *testdriver.GetDynamicProvisionStorageClassResponse
+	err := g.call("GetDynamicProvisionStorageClass", func(ctx context.Context) (err error) {
+		response, err = g.client.GetDynamicProvisionStorageClass(ctx, &testdriver.GetDynamicProvisionStorageClassRequest{
+			TestId: g.testID(config),
+			FsType: fsType,
+		})
+		return
+	})
+	g.skipIfUnimplemented(err)
+	framework.ExpectNoError(err)
+
+	if len(response.StorageClass) == 0 {
+		return nil
+	}
+	sc := &storagev1.StorageClass{}
+	decode("GetDynamicProvisionStorageClass", response.StorageClass, sc)
+	if sc.Provisioner == "" {
+		framework.Failf("GetDynamicProvisionStorageClass: provisioner not set")
+	}
+	// Ensure that we can load more than once as required for
+	// GetDynamicProvisionStorageClass by adding a random suffix.
+	if sc.Name == "" {
+		sc.Name = config.Framework.Namespace.Name + "-" + sc.Provisioner + "-sc"
+	}
+	sc.Name = names.SimpleNameGenerator.GenerateName(sc.Name + "-")
+	return sc
+}
+
+func (g *grpcDriver) GetSnapshotClass(config *testsuites.PerTestConfig) *unstructured.Unstructured {
+	var response *testdriver.GetSnapshotClassResponse
+	err := g.call("GetSnapshotClass", func(ctx context.Context) (err error) {
+		response, err = g.client.GetSnapshotClass(ctx, &testdriver.GetSnapshotClassRequest{
+			TestId: g.testID(config),
+		})
+		return
+	})
+	g.skipIfUnimplemented(err)
+	framework.ExpectNoError(err)
+
+	if len(response.SnapshotClass) == 0 {
+		return nil
+	}
+	snapshotClass := &unstructured.Unstructured{}
+	decode("GetSnapshotClass", response.SnapshotClass, &snapshotClass.Object)
+	if snapshotClass.GetKind() != "VolumeSnapshotClass" {
+		framework.Failf("GetSnapshotClass: expected a VolumeSnapshotClass, got kind %q", snapshotClass.GetKind())
+	}
+	if snapshotClass.GetName() == "" {
+		snapshotClass.SetName(config.Framework.Namespace.Name + "-" + g.driverInfo.Name + "-vsc")
+	}
+	snapshotClass.SetName(names.SimpleNameGenerator.GenerateName(snapshotClass.GetName() + "-"))
+	return snapshotClass
+}
+
+func (g *grpcDriver) GetClaimSize() string {
+	return g.claimSize
+}
+
+// testID returns the ID that the service assigned to the current test.
+func (g *grpcDriver) testID(config *testsuites.PerTestConfig) string {
+	testID, ok := g.testIDs[config.Framework]
+	if !ok {
+		framework.Failf("Driver %q: no test prepared for namespace %s", g.driverInfo.Name, config.Framework.Namespace.Name)
+	}
+	return testID
+}
+
+// volume returns the volume that CreateVolume created for the test
+// suite which now passes it to the given method.
+func (g *grpcDriver) volume(method string, volume testsuites.TestVolume) *grpcVolume {
+	gv, ok := volume.(*grpcVolume)
+	if !ok {
+		framework.Failf("%s: Driver %q needs a volume created by its CreateVolume, got %T", method, g.driverInfo.Name, volume)
+	}
+	return gv
+}
+
+// call invokes one method of the service with the configured timeout.
+func (g *grpcDriver) call(method string, call func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	if err := call(ctx); err != nil {
+		return errors.Wrap(err, method)
+	}
+	return nil
+}
+
+// skipIfUnimplemented skips the current test when the service does not
+// implement the method that was called.
+func (g *grpcDriver) skipIfUnimplemented(err error) {
+	if status.Code(errors.Cause(err)) == codes.Unimplemented {
+		framework.Skipf("Driver %q does not support this test: %v - skipping", g.driverInfo.Name, err)
+	}
+}
+
+// decode parses the JSON or YAML encoded object returned by a method.
+func decode(method string, data []byte, into interface{}) {
+	if err := yaml.Unmarshal(data, into); err != nil {
+		framework.Failf("%s: decoding %T: %v\n%s", method, into, err, data)
+	}
+}
+
+// String is used in log messages.
+func (v *grpcVolume) String() string {
+	return fmt.Sprintf("%s/%s", v.testID, v.volumeID)
+}
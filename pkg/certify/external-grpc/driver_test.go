@@ -0,0 +1,60 @@
+package externalGRPC
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wongma7/csi-certify/pkg/certify/external-grpc/example"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+func TestConnect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grpc-testdriver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "testdriver.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	example.NewServer("csi-hostpath").Register(server)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	driver, err := Connect("unix://" + socket)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	g := driver.(*grpcDriver)
+	if g.driverInfo.Name != "csi-hostpath" {
+		t.Errorf("expected name csi-hostpath, got %q", g.driverInfo.Name)
+	}
+	if !g.driverInfo.Capabilities[testsuites.CapPersistence] {
+		t.Errorf("expected persistence capability, got %v", g.driverInfo.Capabilities)
+	}
+	if !g.driverInfo.SupportedFsType.Has("") {
+		t.Errorf("expected default fsType, got %v", g.driverInfo.SupportedFsType.List())
+	}
+	if !g.volumeTypes.Has("DynamicPV") || !g.snapshots {
+		t.Errorf("expected dynamic provisioning and snapshots, got %v and %v", g.volumeTypes.List(), g.snapshots)
+	}
+	if g.GetClaimSize() != "1Gi" {
+		t.Errorf("expected claim size 1Gi, got %q", g.GetClaimSize())
+	}
+
+	if err := g.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if state := g.conn.GetState(); state != connectivity.Shutdown {
+		t.Errorf("expected closed connection, got state %v", state)
+	}
+}
@@ -0,0 +1,158 @@
+// Package example contains a reference implementation of the gRPC
+// TestDriver service. It describes the HostPath CSI plugin after it
+// was installed with the manifests in pkg/certify/driver/manifests/hostpath
+// and supports dynamic provisioning and snapshots.
+//
+// Vendors can copy it into their own repo as a starting point.
+package example
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/wongma7/csi-certify/pkg/certify/external-grpc/testdriver"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/yaml"
+)
+
+// Server implements testdriver.TestDriverServer.
+type Server struct {
+	// DriverName is the name of the installed CSI driver.
+	DriverName string
+
+	mutex  sync.Mutex
+	nextID int
+	tests  map[string]*testdriver.PrepareTestRequest
+}
+
+var _ testdriver.TestDriverServer = &Server{}
+
+// NewServer returns a Server for the given CSI driver.
+func NewServer(driverName string) *Server {
+	return &Server{
+		DriverName: driverName,
+		tests:      map[string]*testdriver.PrepareTestRequest{},
+	}
+}
+
+// Register adds the TestDriver service to a gRPC server.
+func (s *Server) Register(server *grpc.Server) {
+	testdriver.RegisterTestDriverServer(server, s)
+}
+
+func (s *Server) GetDriverInfo(ctx context.Context, req *testdriver.GetDriverInfoRequest) (*testdriver.GetDriverInfoResponse, error) {
+	return &testdriver.GetDriverInfoResponse{
+		Name:             s.DriverName,
+		MaxFileSize:      100 * 1024 * 1024, // testpatterns.FileSizeMedium
+		SupportedFsTypes: []string{""},
+		Capabilities:     []string{"persistence", "dataSource", "multipods"},
+		ClaimSize:        "1Gi",
+		VolumeTypes:      []string{"DynamicPV"},
+		DynamicSnapshots: true,
+	}, nil
+}
+
+func (s *Server) SkipUnsupportedTest(ctx context.Context, req *testdriver.SkipUnsupportedTestRequest) (*testdriver.SkipUnsupportedTestResponse, error) {
+	if req.GetPattern().GetVolMode() == "Block" {
+		return &testdriver.SkipUnsupportedTestResponse{SkipReason: "raw block volumes are not supported"}, nil
+	}
+	return &testdriver.SkipUnsupportedTestResponse{}, nil
+}
+
+func (s *Server) PrepareTest(ctx context.Context, req *testdriver.PrepareTestRequest) (*testdriver.PrepareTestResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextID++
+	id := fmt.Sprintf("test-%d", s.nextID)
+	s.tests[id] = req
+	return &testdriver.PrepareTestResponse{TestId: id}, nil
+}
+
+func (s *Server) CleanupTest(ctx context.Context, req *testdriver.CleanupTestRequest) (*testdriver.CleanupTestResponse, error) {
+	if _, err := s.getTest(req.TestId); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.tests, req.TestId)
+	return &testdriver.CleanupTestResponse{}, nil
+}
+
+func (s *Server) CreateVolume(ctx context.Context, req *testdriver.CreateVolumeRequest) (*testdriver.CreateVolumeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "pre-provisioned volumes are not supported")
+}
+
+func (s *Server) DeleteVolume(ctx context.Context, req *testdriver.DeleteVolumeRequest) (*testdriver.DeleteVolumeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "pre-provisioned volumes are not supported")
+}
+
+func (s *Server) GetPersistentVolumeSource(ctx context.Context, req *testdriver.GetPersistentVolumeSourceRequest) (*testdriver.GetPersistentVolumeSourceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "pre-provisioned volumes are not supported")
+}
+
+func (s *Server) GetVolumeSource(ctx context.Context, req *testdriver.GetVolumeSourceRequest) (*testdriver.GetVolumeSourceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "inline volumes are not supported")
+}
+
+func (s *Server) GetDynamicProvisionStorageClass(ctx context.Context, req *testdriver.GetDynamicProvisionStorageClassRequest) (*testdriver.GetDynamicProvisionStorageClassResponse, error) {
+	test, err := s.getTest(req.TestId)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := map[string]interface{}{
+		"apiVersion": "storage.k8s.io/v1",
+		"kind":       "StorageClass",
+		"metadata": map[string]interface{}{
+			"name": test.Namespace + "-" + s.DriverName + "-sc",
+		},
+		"provisioner": s.DriverName,
+	}
+	if req.FsType != "" {
+		sc["parameters"] = map[string]interface{}{
+			"csi.storage.k8s.io/fstype": req.FsType,
+		}
+	}
+	data, err := yaml.Marshal(sc)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &testdriver.GetDynamicProvisionStorageClassResponse{StorageClass: data}, nil
+}
+
+func (s *Server) GetSnapshotClass(ctx context.Context, req *testdriver.GetSnapshotClassRequest) (*testdriver.GetSnapshotClassResponse, error) {
+	test, err := s.getTest(req.TestId)
+	if err != nil {
+		return nil, err
+	}
+
+	vsc := map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1alpha1",
+		"kind":       "VolumeSnapshotClass",
+		"metadata": map[string]interface{}{
+			"name": test.Namespace + "-" + s.DriverName + "-vsc",
+		},
+		"snapshotter": s.DriverName,
+	}
+	data, err := yaml.Marshal(vsc)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &testdriver.GetSnapshotClassResponse{SnapshotClass: data}, nil
+}
+
+func (s *Server) getTest(id string) (*testdriver.PrepareTestRequest, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	test, ok := s.tests[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown test %q", id)
+	}
+	return test, nil
+}
@@ -6,17 +6,15 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	customTest "github.com/wongma7/csi-certify/pkg/certify/test"
 	"github.com/wongma7/csi-certify/pkg/certify/utils"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/storage/names"
-	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	"k8s.io/kubernetes/test/e2e/framework"
 	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
 	"k8s.io/kubernetes/test/e2e/storage/testsuites"
 
-	"io/ioutil"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -46,7 +44,7 @@ func (d DriverDefParameter) Set(filename string) error {
 
 	description := "External Storage " + testsuites.GetDriverNameWithFeatureTags(driver)
 	Describe(description, func() {
-		testsuites.DefineTestSuite(driver, utils.CSITestSuites)
+		customTest.DefineTestSuites(driver)
 	})
 
 	return nil
@@ -54,30 +52,11 @@ func (d DriverDefParameter) Set(filename string) error {
 }
 
 func (d DriverDefParameter) loadDriverDefinition(filename string) (*driverDefinition, error) {
-	if filename == "" {
-		return nil, errors.New("missing file name")
-	}
-	data, err := ioutil.ReadFile(filename)
+	definition, err := utils.LoadDriverDefinition(filename)
 	if err != nil {
 		return nil, err
 	}
-	// Some reasonable defaults follow.
-	driver := &driverDefinition{
-		utils.DriverDefinition{
-			DriverInfo: testsuites.DriverInfo{
-				SupportedFsType: sets.NewString(
-					"", // Default fsType
-				),
-			},
-			ClaimSize: "5Gi",
-		},
-	}
-	// TODO: strict checking of the file content once https://github.com/kubernetes/kubernetes/pull/71589
-	// or something similar is merged.
-	if err := runtime.DecodeInto(legacyscheme.Codecs.UniversalDecoder(), data, &driver.DriverDefinition); err != nil {
-		return nil, errors.Wrap(err, filename)
-	}
-	return driver, nil
+	return &driverDefinition{*definition}, nil
 }
 
 var _ testsuites.TestDriver = &driverDefinition{}
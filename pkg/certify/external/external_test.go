@@ -0,0 +1,29 @@
+package external
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wongma7/csi-certify/pkg/certify/fake"
+	"k8s.io/kubernetes/test/e2e/framework/testfiles"
+)
+
+func TestSkipUnsupportedTest(t *testing.T) {
+	testfiles.AddFileSource(testfiles.RootFileSource{Root: "."})
+
+	for _, filename := range []string{
+		"driver-def.yaml",
+		"testdata/from-file.yaml",
+		"testdata/no-storageclass.yaml",
+	} {
+		t.Run(filename, func(t *testing.T) {
+			driver, err := DriverDefParam.loadDriverDefinition(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := strings.TrimSuffix(filepath.Base(filename), ".yaml") + ".golden"
+			fake.CheckGolden(t, filepath.Join("testdata", golden), fake.Report(driver))
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// Package fake runs TestDrivers through all test patterns without a
+// cluster and records what they do. Tests use it together with golden
+// files to catch regressions in skip logic and in the generated
+// storage and snapshot classes.
+package fake
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/framework/ginkgowrapper"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"sigs.k8s.io/yaml"
+)
+
+// UpdateEnv is the environment variable which makes CheckGolden
+// overwrite golden files instead of comparing against them.
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Patterns contains all test patterns defined by the testpatterns
+// package.
+var Patterns = []testpatterns.TestPattern{
+	testpatterns.DefaultFsInlineVolume,
+	testpatterns.DefaultFsPreprovisionedPV,
+	testpatterns.DefaultFsDynamicPV,
+	testpatterns.Ext3InlineVolume,
+	testpatterns.Ext3PreprovisionedPV,
+	testpatterns.Ext3DynamicPV,
+	testpatterns.Ext4InlineVolume,
+	testpatterns.Ext4PreprovisionedPV,
+	testpatterns.Ext4DynamicPV,
+	testpatterns.XfsInlineVolume,
+	testpatterns.XfsPreprovisionedPV,
+	testpatterns.XfsDynamicPV,
+	testpatterns.FsVolModePreprovisionedPV,
+	testpatterns.FsVolModeDynamicPV,
+	testpatterns.BlockVolModePreprovisionedPV,
+	testpatterns.BlockVolModeDynamicPV,
+	testpatterns.DynamicSnapshot,
+}
+
+// Framework returns a framework instance with a fixed namespace and
+// the fake clientset from client-go, which starts empty. PrepareTest
+// is never called because it deploys drivers and waits for them.
+func Framework() *framework.Framework {
+	return &framework.Framework{
+		BaseName:   "fake",
+		UniqueName: "fake-1234",
+		ClientSet:  fakeclientset.NewSimpleClientset(),
+		Namespace: &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "fake-1234",
+			},
+		},
+	}
+}
+
+// Report runs the driver through all patterns and describes for each
+// one whether the tests would be skipped and, if not, which storage
+// and snapshot classes the driver generates.
+func Report(driver testsuites.TestDriver) string {
+	gomega.RegisterFailHandler(ginkgowrapper.Fail)
+
+	config := &testsuites.PerTestConfig{
+		Driver:    driver,
+		Prefix:    "fake",
+		Framework: Framework(),
+	}
+
+	var buffer bytes.Buffer
+	for _, pattern := range Patterns {
+		decision := call(func() {
+			utils.SkipUnsupportedTest(driver, pattern)
+		})
+		fmt.Fprintf(&buffer, "%s: %s\n", pattern.Name, decision)
+		if decision != "run" {
+			continue
+		}
+		if _, ok := driver.(testsuites.DynamicPVTestDriver); ok && pattern.VolType == testpatterns.DynamicPV && pattern.SnapshotType == "" {
+			reportClass(&buffer, "StorageClass", func() interface{} {
+				return driver.(testsuites.DynamicPVTestDriver).GetDynamicProvisionStorageClass(config, pattern.FsType)
+			})
+		}
+		if _, ok := driver.(testsuites.SnapshottableTestDriver); ok && pattern.SnapshotType == testpatterns.DynamicCreatedSnapshot {
+			reportClass(&buffer, "VolumeSnapshotClass", func() interface{} {
+				return driver.(testsuites.SnapshottableTestDriver).GetSnapshotClass(config)
+			})
+		}
+	}
+	return buffer.String()
+}
+
+// reportClass adds the object returned by get to the report, unless
+// get skips or fails.
+func reportClass(buffer *bytes.Buffer, kind string, get func() interface{}) {
+	var obj interface{}
+	// Names with a random suffix have to be the same each time.
+	utilrand.Seed(1)
+	result := call(func() {
+		obj = get()
+	})
+	if obj == nil {
+		fmt.Fprintf(buffer, "  %s: %s\n", kind, result)
+		return
+	}
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		fmt.Fprintf(buffer, "  %s: %v\n", kind, err)
+		return
+	}
+	fmt.Fprintf(buffer, "  %s:\n", kind)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		fmt.Fprintf(buffer, "    %s\n", line)
+	}
+}
+
+// call invokes the function and turns the panics raised by
+// framework.Skipf and framework.Failf into "skip: <reason>" and
+// "fail: <reason>". Other panics are not caught.
+func call(f func()) (result string) {
+	defer func() {
+		switch r := recover().(type) {
+		case nil:
+		case ginkgowrapper.SkipPanic:
+			result = "skip: " + r.Message
+		case ginkgowrapper.FailurePanic:
+			result = "fail: " + stripTimestamp(r.Message)
+		default:
+			panic(r)
+		}
+	}()
+	f()
+	return "run"
+}
+
+// stripTimestamp removes the time stamp that framework.Failf puts in
+// front of the message.
+func stripTimestamp(message string) string {
+	parts := strings.SplitN(message, ": ", 2)
+	if len(parts) == 2 && strings.Count(parts[0], ":") == 2 {
+		return parts[1]
+	}
+	return message
+}
+
+// CheckGolden compares the report against the content of the golden
+// file. The file gets written instead when UPDATE_GOLDEN is set.
+func CheckGolden(t *testing.T, filename string, report string) {
+	if os.Getenv(UpdateEnv) != "" {
+		if err := ioutil.WriteFile(filename, []byte(report), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("%v (run with %s=1 to create it)", err, UpdateEnv)
+	}
+	if string(expected) != report {
+		t.Errorf("%s is out of date (run with %s=1 to update it), got:\n%s", filename, UpdateEnv, report)
+	}
+}
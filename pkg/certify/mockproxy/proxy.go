@@ -0,0 +1,156 @@
+// Package mockproxy implements a gRPC proxy which sits between the
+// users of a CSI driver (kubelet and the sidecars) and the driver
+// itself. It forwards calls unchanged unless a rule for the method
+// says otherwise, which makes it possible to let individual CSI calls
+// of the CSI mock driver fail, run slowly or report no capabilities.
+//
+// Messages are forwarded without decoding them, so the proxy works
+// with any CSI version and with any other gRPC service.
+package mockproxy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rules say how the proxy handles individual methods, identified by
+// their full name like "/csi.v1.Node/NodePublishVolume". Delays apply
+// before errors and empty responses.
+type Rules struct {
+	// Errors are returned instead of forwarding the call.
+	Errors map[string]codes.Code
+	// Delays are waited for before forwarding the call.
+	Delays map[string]time.Duration
+	// Empty methods get an empty response instead of forwarding the
+	// call. For GetPluginCapabilities, ControllerGetCapabilities and
+	// NodeGetCapabilities that means that no capabilities are
+	// reported.
+	Empty map[string]bool
+}
+
+// ParseRules parses rules from the command line. errs have the format
+// <method>=<code name>, for example
+// /csi.v1.Node/NodePublishVolume=Internal, delays
+// <method>=<duration> and empty contains method names.
+func ParseRules(errs, delays, empty []string) (Rules, error) {
+	rules := Rules{
+		Errors: map[string]codes.Code{},
+		Delays: map[string]time.Duration{},
+		Empty:  map[string]bool{},
+	}
+	for _, rule := range errs {
+		method, value, err := splitRule(rule)
+		if err != nil {
+			return rules, err
+		}
+		code, ok := parseCode(value)
+		if !ok {
+			return rules, errors.Errorf("%q: unknown gRPC status code %q", rule, value)
+		}
+		rules.Errors[method] = code
+	}
+	for _, rule := range delays {
+		method, value, err := splitRule(rule)
+		if err != nil {
+			return rules, err
+		}
+		delay, err := time.ParseDuration(value)
+		if err != nil {
+			return rules, errors.Wrapf(err, "%q", rule)
+		}
+		rules.Delays[method] = delay
+	}
+	for _, method := range empty {
+		if !strings.HasPrefix(method, "/") {
+			return rules, errors.Errorf("%q: method must be a full name like /csi.v1.Node/NodePublishVolume", method)
+		}
+		rules.Empty[method] = true
+	}
+	return rules, nil
+}
+
+func splitRule(rule string) (method, value string, err error) {
+	parts := strings.SplitN(rule, "=", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") || parts[1] == "" {
+		return "", "", errors.Errorf("%q: must have the format /<service>/<method>=<value>", rule)
+	}
+	return parts[0], parts[1], nil
+}
+
+// parseCode finds the code with the given name, for example Internal
+// or DeadlineExceeded.
+func parseCode(name string) (codes.Code, bool) {
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if code.String() == name {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+// NewServer returns a gRPC server without services of its own which
+// forwards all calls to conn, as modified by the rules. Only unary
+// calls are supported, which is all that CSI uses.
+func NewServer(conn *grpc.ClientConn, rules Rules) *grpc.Server {
+	p := &proxy{conn: conn, rules: rules}
+	return grpc.NewServer(grpc.CustomCodec(rawCodec{}), grpc.UnknownServiceHandler(p.handle))
+}
+
+type proxy struct {
+	conn  *grpc.ClientConn
+	rules Rules
+}
+
+func (p *proxy) handle(srv interface{}, stream grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "unknown method")
+	}
+	var request []byte
+	if err := stream.RecvMsg(&request); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	if delay, ok := p.rules.Delays[method]; ok {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+		}
+	}
+	if code, ok := p.rules.Errors[method]; ok {
+		return status.Error(code, fmt.Sprintf("%s failed by csi-mock-proxy", method))
+	}
+	var response []byte
+	if !p.rules.Empty[method] {
+		if err := p.conn.Invoke(ctx, method, &request, &response, grpc.CallCustomCodec(rawCodec{})); err != nil {
+			return err
+		}
+	}
+	return stream.SendMsg(&response)
+}
+
+// rawCodec passes the encoded messages through. It claims to be the
+// proto codec, because its name becomes the content-subtype of the
+// forwarded calls.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	return *v.(*[]byte), nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	*v.(*[]byte) = append([]byte(nil), data...)
+	return nil
+}
+
+func (rawCodec) String() string {
+	return "proto"
+}
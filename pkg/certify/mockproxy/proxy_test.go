@@ -0,0 +1,126 @@
+package mockproxy
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wongma7/csi-certify/pkg/certify/external-grpc/example"
+	"github.com/wongma7/csi-certify/pkg/certify/external-grpc/testdriver"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestProxy runs the proxy in front of the reference gRPC TestDriver,
+// which is as good a service as any other.
+func TestProxy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mockproxy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	backend := grpc.NewServer()
+	example.NewServer("csi-hostpath").Register(backend)
+	defer backend.Stop()
+	backendConn := serve(t, backend, filepath.Join(dir, "backend.sock"))
+	defer backendConn.Close()
+
+	rules, err := ParseRules(
+		[]string{"/certify.testdriver.v1.TestDriver/SkipUnsupportedTest=Unavailable"},
+		[]string{"/certify.testdriver.v1.TestDriver/PrepareTest=200ms"},
+		[]string{"/certify.testdriver.v1.TestDriver/CleanupTest"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := NewServer(backendConn, rules)
+	defer proxy.Stop()
+	conn := serve(t, proxy, filepath.Join(dir, "proxy.sock"))
+	defer conn.Close()
+	client := testdriver.NewTestDriverClient(conn)
+	ctx := context.Background()
+
+	info, err := client.GetDriverInfo(ctx, &testdriver.GetDriverInfoRequest{})
+	if err != nil {
+		t.Fatalf("GetDriverInfo: %v", err)
+	}
+	if info.Name != "csi-hostpath" || info.ClaimSize != "1Gi" {
+		t.Errorf("GetDriverInfo not forwarded, got %+v", info)
+	}
+
+	_, err = client.SkipUnsupportedTest(ctx, &testdriver.SkipUnsupportedTestRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected SkipUnsupportedTest to fail with Unavailable, got %v", err)
+	}
+
+	start := time.Now()
+	if _, err := client.PrepareTest(ctx, &testdriver.PrepareTestRequest{}); err != nil {
+		t.Errorf("PrepareTest: %v", err)
+	}
+	if duration := time.Since(start); duration < 200*time.Millisecond {
+		t.Errorf("expected PrepareTest to take at least 200ms, took %v", duration)
+	}
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.PrepareTest(shortCtx, &testdriver.PrepareTestRequest{}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected PrepareTest to time out, got %v", err)
+	}
+
+	// The backend would reject the unknown test ID.
+	if _, err := client.CleanupTest(ctx, &testdriver.CleanupTestRequest{TestId: "no-such-test"}); err != nil {
+		t.Errorf("expected empty response for CleanupTest, got %v", err)
+	}
+
+	// Errors of the backend get passed through.
+	_, err = client.GetVolumeSource(ctx, &testdriver.GetVolumeSourceRequest{})
+	if status.Code(err) == codes.OK || status.Code(err) == codes.Unknown {
+		t.Errorf("expected error with status code from backend, got %v", err)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	for _, test := range []struct {
+		errs, delays, empty []string
+	}{
+		{errs: []string{"/csi.v1.Node/NodePublishVolume=NoSuchCode"}},
+		{errs: []string{"NodePublishVolume=Internal"}},
+		{delays: []string{"/csi.v1.Controller/CreateVolume=soon"}},
+		{delays: []string{"/csi.v1.Controller/CreateVolume"}},
+		{empty: []string{"GetPluginCapabilities"}},
+	} {
+		if _, err := ParseRules(test.errs, test.delays, test.empty); err == nil {
+			t.Errorf("expected error for %+v", test)
+		}
+	}
+
+	rules, err := ParseRules([]string{"/csi.v1.Node/NodePublishVolume=Internal"}, []string{"/csi.v1.Controller/CreateVolume=90s"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules.Errors["/csi.v1.Node/NodePublishVolume"] != codes.Internal || rules.Delays["/csi.v1.Controller/CreateVolume"] != 90*time.Second {
+		t.Errorf("unexpected rules %+v", rules)
+	}
+}
+
+// serve runs the server on a Unix domain socket and returns a
+// connection to it.
+func serve(t *testing.T, server *grpc.Server, socket string) *grpc.ClientConn {
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	conn, err := grpc.Dial(socket, grpc.WithInsecure(), grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+		return net.DialTimeout("unix", addr, timeout)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
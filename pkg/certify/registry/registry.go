@@ -0,0 +1,118 @@
+// Package registry contains the TestDriver implementations that can be
+// selected with --testdriver. Drivers add themselves in an init
+// function, so linking a driver package into the test binary with a
+// blank import is enough to make it available:
+//
+//	import _ "github.com/wongma7/csi-certify/pkg/certify/driver"
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+// Factory creates a new instance of a TestDriver.
+type Factory func() testsuites.TestDriver
+
+// Metadata describes a registered TestDriver.
+type Metadata struct {
+	// Description is a short, human readable summary of the driver.
+	Description string
+
+	// RequiredFeatures lists cluster features, for example feature
+	// gates, that must be enabled for the driver to work. Tests of
+	// the driver get tagged with [Feature:<name>] for each of them.
+	RequiredFeatures []string
+
+	// ParallelSafe must be true when tests of the driver can run
+	// in parallel with other tests. Otherwise they get tagged with
+	// [Serial].
+	ParallelSafe bool
+
+	// OptIn drivers only run when selected explicitly with
+	// --testdriver, for example because they are expected to fail.
+	OptIn bool
+}
+
+// Entry is a registered TestDriver.
+type Entry struct {
+	Name    string
+	Factory Factory
+	Metadata
+}
+
+var (
+	mutex   sync.Mutex
+	drivers = map[string]Entry{}
+)
+
+// Register makes a TestDriver available under the given name. It
+// panics when the name is empty or already registered, because that
+// is a programming error which should be found right away.
+func Register(name string, factory Factory, metadata Metadata) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if name == "" {
+		panic("registry: empty TestDriver name")
+	}
+	if factory == nil {
+		panic(fmt.Sprintf("registry: nil factory for TestDriver %q", name))
+	}
+	if _, ok := drivers[name]; ok {
+		panic(fmt.Sprintf("registry: TestDriver %q registered twice", name))
+	}
+	drivers[name] = Entry{
+		Name:     name,
+		Factory:  factory,
+		Metadata: metadata,
+	}
+}
+
+// Lookup returns the TestDriver with the given name.
+func Lookup(name string) (Entry, bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	entry, ok := drivers[name]
+	return entry, ok
+}
+
+// Names returns the sorted names of all registered TestDrivers.
+func Names() []string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var names []string
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Entries returns all registered TestDrivers, sorted by name.
+func Entries() []Entry {
+	var entries []Entry
+	for _, name := range Names() {
+		entry, _ := Lookup(name)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Tags returns the Ginkgo tags that correspond to the metadata, for
+// example " [Serial] [Feature:CSIBlockVolume]".
+func (m Metadata) Tags() string {
+	var tags string
+	if !m.ParallelSafe {
+		tags += " [Serial]"
+	}
+	for _, feature := range m.RequiredFeatures {
+		tags += fmt.Sprintf(" [Feature:%s]", feature)
+	}
+	return tags
+}
@@ -0,0 +1,35 @@
+package registry
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+func TestRegister(t *testing.T) {
+	factory := func() testsuites.TestDriver { return nil }
+	Register("b", factory, Metadata{Description: "second", ParallelSafe: true})
+	Register("a", factory, Metadata{Description: "first", RequiredFeatures: []string{"CSIBlockVolume"}})
+
+	if names := Names(); !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("expected names [a b], got %v", names)
+	}
+	entry, ok := Lookup("a")
+	if !ok || entry.Description != "first" {
+		t.Errorf("expected entry a, got %+v", entry)
+	}
+	if tags := entry.Tags(); tags != " [Serial] [Feature:CSIBlockVolume]" {
+		t.Errorf("unexpected tags %q", tags)
+	}
+	if _, ok := Lookup("c"); ok {
+		t.Error("unexpected entry c")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a name twice did not panic")
+		}
+	}()
+	Register("a", factory, Metadata{})
+}
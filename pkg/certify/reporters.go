@@ -0,0 +1,27 @@
+package certify
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"k8s.io/kubernetes/test/e2e/framework"
+)
+
+// customReporters returns the reporters which get used in addition to
+// the default one. With --report-dir, the results of all tests get
+// written as JUnit XML to junit_<report prefix><node>.xml, like the
+// Kubernetes e2e tests do.
+func customReporters() ([]Reporter, error) {
+	if framework.TestContext.ReportDir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(framework.TestContext.ReportDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating report directory: %v", err)
+	}
+	filename := fmt.Sprintf("junit_%v%02d.xml", framework.TestContext.ReportPrefix, config.GinkgoConfig.ParallelNode)
+	return []Reporter{reporters.NewJUnitReporter(path.Join(framework.TestContext.ReportDir, filename))}, nil
+}
@@ -0,0 +1,446 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+// capabilityConstants are the names under which the testsuites package
+// defines the capabilities.
+var capabilityConstants = map[testsuites.Capability]string{
+	testsuites.CapPersistence: "CapPersistence",
+	testsuites.CapBlock:       "CapBlock",
+	testsuites.CapFsGroup:     "CapFsGroup",
+	testsuites.CapExec:        "CapExec",
+	testsuites.CapDataSource:  "CapDataSource",
+	testsuites.CapMultiPODs:   "CapMultiPODs",
+}
+
+// FilesFromDefinition returns the content of a Go TestDriver that
+// behaves exactly like the external TestDriver for the given
+// DriverDefinition, indexed by its path relative to the root of the
+// repo. The DriverDefinition can be loaded with
+// utils.LoadDriverDefinition.
+func FilesFromDefinition(name string, definition *utils.DriverDefinition) (map[string][]byte, error) {
+	o := Options{
+		Name:       name,
+		DriverName: definition.DriverInfo.Name,
+		DynamicPV:  definition.StorageClass.FromName || definition.StorageClass.FromFile != "",
+	}
+	// Snapshot tests need dynamic provisioning and get skipped
+	// without it anyway.
+	o.Snapshots = o.DynamicPV && definition.SnapshotClass.FromName
+	if o.DriverName == "" {
+		return nil, errors.New("DriverInfo.Name not set")
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	var source bytes.Buffer
+	data := definitionData{templateData{o}, definition}
+	if err := definitionTemplate.Execute(&source, data); err != nil {
+		return nil, errors.Wrap(err, "executing template")
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "formatting generated code:\n%s", source.String())
+	}
+
+	return map[string][]byte{
+		filepath.Join("pkg", "certify", "driver", o.Name+"_driver.go"): formatted,
+	}, nil
+}
+
+type definitionData struct {
+	templateData
+	Definition *utils.DriverDefinition
+}
+
+// StringSet returns Go code that creates the set.
+func (d definitionData) StringSet(set sets.String) string {
+	var items []string
+	for _, item := range set.List() {
+		items = append(items, fmt.Sprintf("%q,", item))
+	}
+	if len(items) == 0 {
+		return "sets.NewString()"
+	}
+	return "sets.NewString(\n" + strings.Join(items, "\n") + "\n)"
+}
+
+// HasVolumeStats, HasNodePlugin, HasControllerPlugin, HasScale and
+// HasIOBenchmark tell whether the DriverDefinition sets the values of
+// the corresponding TestDriver interface. The suites treat unset
+// values like a TestDriver which does not implement the interface, so
+// the interface is only implemented when needed.
+func (d definitionData) HasVolumeStats() bool {
+	return d.Definition.VolumeStats != utils.VolumeStatsTolerances{}
+}
+
+func (d definitionData) HasNodePlugin() bool {
+	return d.Definition.NodePlugin != utils.PodSelector{}
+}
+
+func (d definitionData) HasControllerPlugin() bool {
+	return d.Definition.ControllerPlugin != utils.PodSelector{} || d.Definition.VolumeLister != utils.PodCommand{}
+}
+
+func (d definitionData) HasScale() bool {
+	return d.Definition.Scale != utils.ScaleParameters{}
+}
+
+func (d definitionData) HasIOBenchmark() bool {
+	b := d.Definition.IOBenchmark
+	return b.FileSize != nil || b.Runtime != nil || len(b.Thresholds) > 0
+}
+
+// NeedsQuantity tells whether the generated code parses quantities.
+func (d definitionData) NeedsQuantity() bool {
+	if d.Definition.VolumeStats.UsedBytes != nil || d.Definition.IOBenchmark.FileSize != nil {
+		return true
+	}
+	for _, thresholds := range d.Definition.IOBenchmark.Thresholds {
+		if thresholds.MinBandwidth != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsDuration tells whether the generated code contains durations.
+func (d definitionData) NeedsDuration() bool {
+	if d.Definition.IOBenchmark.Runtime != nil {
+		return true
+	}
+	for _, thresholds := range d.Definition.IOBenchmark.Thresholds {
+		if thresholds.MaxLatency != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsUtils tells whether the generated code uses the utils package.
+func (d definitionData) NeedsUtils() bool {
+	return d.HasVolumeStats() || d.HasNodePlugin() || d.HasControllerPlugin() || d.HasScale() || d.HasIOBenchmark()
+}
+
+// PodSelector returns Go code for the selector.
+func (d definitionData) PodSelector(selector utils.PodSelector) string {
+	return fmt.Sprintf("testUtils.PodSelector{Namespace: %q, LabelSelector: %q}", selector.Namespace, selector.LabelSelector)
+}
+
+// PodCommand returns Go code for the command.
+func (d definitionData) PodCommand(command utils.PodCommand) string {
+	return fmt.Sprintf("testUtils.PodCommand{Pods: %s, Container: %q, Command: %q}", d.PodSelector(command.Pods), command.Container, command.Command)
+}
+
+// Duration returns Go code for the duration.
+func (d definitionData) Duration(duration time.Duration) string {
+	for _, unit := range []struct {
+		duration time.Duration
+		name     string
+	}{
+		{time.Hour, "Hour"},
+		{time.Minute, "Minute"},
+		{time.Second, "Second"},
+		{time.Millisecond, "Millisecond"},
+		{time.Microsecond, "Microsecond"},
+	} {
+		if duration != 0 && duration%unit.duration == 0 {
+			return fmt.Sprintf("%d * time.%s", duration/unit.duration, unit.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", duration)
+}
+
+// Capabilities returns the map entries for all enabled capabilities.
+func (d definitionData) Capabilities() string {
+	var entries []string
+	for capability, enabled := range d.Definition.DriverInfo.Capabilities {
+		if !enabled {
+			continue
+		}
+		key := fmt.Sprintf("testsuites.Capability(%q)", capability)
+		if constant, ok := capabilityConstants[capability]; ok {
+			key = "testsuites." + constant
+		}
+		entries = append(entries, key+": true,")
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, "\n")
+}
+
+var definitionTemplate = template.Must(template.New("definition").Parse(`package driver
+
+// Generated from a DriverDefinition, behaves like --driverdef with
+// that file.
+
+import (
+{{- if .NeedsDuration}}
+	"time"
+
+{{end -}}
+{{- if .Definition.StorageClass.FromFile}}
+	. "github.com/onsi/gomega"
+{{- end}}
+{{- if .DynamicPV}}
+	storagev1 "k8s.io/api/storage/v1"
+{{- end}}
+{{- if .NeedsQuantity}}
+	"k8s.io/apimachinery/pkg/api/resource"
+{{- end}}
+{{- if .NeedsDuration}}
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+{{- end}}
+{{- if .Snapshots}}
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+{{- end}}
+	"k8s.io/apimachinery/pkg/util/sets"
+{{- if .Definition.StorageClass.FromFile}}
+	"k8s.io/apiserver/pkg/storage/names"
+{{- end}}
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
+{{- if .NeedsUtils}}
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+{{- end}}
+)
+
+func init() {
+	registry.Register({{printf "%q" .Name}}, {{.Init}}, registry.Metadata{
+		Description: {{printf "%q" (printf "%s, converted from a DriverDefinition" .DriverName)}},
+	})
+}
+
+type {{.Type}} struct {
+	driverInfo testsuites.DriverInfo
+}
+
+// {{.Init}} returns {{.Type}} that implements TestDriver interface
+func {{.Init}}() testsuites.TestDriver {
+	return &{{.Type}}{
+		driverInfo: testsuites.DriverInfo{
+			Name:                 {{printf "%q" .Definition.DriverInfo.Name}},
+			FeatureTag:           {{printf "%q" .Definition.DriverInfo.FeatureTag}},
+			MaxFileSize:          {{.Definition.DriverInfo.MaxFileSize}},
+			SupportedFsType:      {{.StringSet .Definition.DriverInfo.SupportedFsType}},
+			SupportedMountOption: {{.StringSet .Definition.DriverInfo.SupportedMountOption}},
+			RequiredMountOption:  {{.StringSet .Definition.DriverInfo.RequiredMountOption}},
+			Capabilities: map[testsuites.Capability]bool{
+				{{.Capabilities}}
+			},
+		},
+	}
+}
+
+var _ testsuites.TestDriver = &{{.Type}}{}
+{{- if .DynamicPV}}
+var _ testsuites.DynamicPVTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .Snapshots}}
+var _ testsuites.SnapshottableTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .HasVolumeStats}}
+var _ testUtils.VolumeStatsTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .HasNodePlugin}}
+var _ testUtils.NodePluginTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .HasControllerPlugin}}
+var _ testUtils.ControllerPluginTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .HasScale}}
+var _ testUtils.ScaleTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .HasIOBenchmark}}
+var _ testUtils.IOBenchmarkTestDriver = &{{.Type}}{}
+{{- end}}
+
+func (d *{{.Type}}) GetDriverInfo() *testsuites.DriverInfo {
+	return &d.driverInfo
+}
+
+func (d *{{.Type}}) SkipUnsupportedTest(pattern testpatterns.TestPattern) {
+	supported := false
+	switch pattern.VolType {
+	case testpatterns.DynamicPV:
+		supported = {{.DynamicPV}}
+	}
+	if !supported {
+		framework.Skipf("Driver %q does not support volume type %q - skipping", d.driverInfo.Name, pattern.VolType)
+	}
+
+	supported = false
+	switch pattern.SnapshotType {
+	case "":
+		supported = true
+	case testpatterns.DynamicCreatedSnapshot:
+		supported = {{.Snapshots}}
+	}
+	if !supported {
+		framework.Skipf("Driver %q does not support snapshot type %q - skipping", d.driverInfo.Name, pattern.SnapshotType)
+	}
+}
+
+{{- if .DynamicPV}}
+
+func (d *{{.Type}}) GetDynamicProvisionStorageClass(config *testsuites.PerTestConfig, fsType string) *storagev1.StorageClass {
+	f := config.Framework
+{{- if .Definition.StorageClass.FromName}}
+
+	provisioner := d.driverInfo.Name
+	parameters := map[string]string{}
+	ns := f.Namespace.Name
+	suffix := provisioner + "-sc"
+	if fsType != "" {
+		parameters["csi.storage.k8s.io/fstype"] = fsType
+	}
+
+	return testsuites.GetStorageClass(provisioner, parameters, nil, ns, suffix)
+{{- else if .Definition.StorageClass.FromFile}}
+	fromFile := {{printf "%q" .Definition.StorageClass.FromFile}}
+
+	items, err := f.LoadFromManifests(fromFile)
+	Expect(err).NotTo(HaveOccurred(), "load storage class from %s", fromFile)
+	Expect(len(items)).To(Equal(1), "exactly one item from %s", fromFile)
+
+	err = f.PatchItems(items...)
+	Expect(err).NotTo(HaveOccurred(), "patch items")
+
+	sc, ok := items[0].(*storagev1.StorageClass)
+	Expect(ok).To(BeTrue(), "storage class from %s", fromFile)
+	// Ensure that we can load more than once as required for
+	// GetDynamicProvisionStorageClass by adding a random suffix.
+	sc.Name = names.SimpleNameGenerator.GenerateName(sc.Name + "-")
+	if fsType != "" {
+		if sc.Parameters == nil {
+			sc.Parameters = map[string]string{}
+		}
+		sc.Parameters["csi.storage.k8s.io/fstype"] = fsType
+	}
+	return sc
+{{- end}}
+}
+
+func (d *{{.Type}}) GetClaimSize() string {
+	return {{printf "%q" .Definition.ClaimSize}}
+}
+{{- end}}
+{{- if .Snapshots}}
+
+func (d *{{.Type}}) GetSnapshotClass(config *testsuites.PerTestConfig) *unstructured.Unstructured {
+	snapshotter := d.driverInfo.Name
+	parameters := map[string]string{}
+	ns := config.Framework.Namespace.Name
+	suffix := snapshotter + "-vsc"
+
+	return testsuites.GetSnapshotClass(snapshotter, parameters, ns, suffix)
+}
+{{- end}}
+
+{{- if .HasVolumeStats}}
+
+func (d *{{.Type}}) GetVolumeStatsTolerances() testUtils.VolumeStatsTolerances {
+	var tolerances testUtils.VolumeStatsTolerances
+{{- with .Definition.VolumeStats.UsedBytes}}
+	tolerances.UsedBytes = d.quantity({{printf "%q" .String}})
+{{- end}}
+{{- with .Definition.VolumeStats.CapacityPercent}}
+	capacityPercent := {{.}}
+	tolerances.CapacityPercent = &capacityPercent
+{{- end}}
+	return tolerances
+}
+{{- end}}
+{{- if .HasNodePlugin}}
+
+func (d *{{.Type}}) GetNodePlugin(config *testsuites.PerTestConfig) testUtils.PodSelector {
+	return {{.PodSelector .Definition.NodePlugin}}
+}
+{{- end}}
+{{- if .HasControllerPlugin}}
+
+func (d *{{.Type}}) GetControllerPlugin(config *testsuites.PerTestConfig) testUtils.PodSelector {
+	return {{.PodSelector .Definition.ControllerPlugin}}
+}
+
+func (d *{{.Type}}) GetVolumeLister(config *testsuites.PerTestConfig) testUtils.PodCommand {
+	return {{.PodCommand .Definition.VolumeLister}}
+}
+{{- end}}
+{{- if .HasScale}}
+
+func (d *{{.Type}}) GetScaleParameters() testUtils.ScaleParameters {
+	return testUtils.ScaleParameters{
+		Count:       {{.Definition.Scale.Count}},
+		Concurrency: {{.Definition.Scale.Concurrency}},
+	}
+}
+{{- end}}
+{{- if .HasIOBenchmark}}
+
+func (d *{{.Type}}) GetIOBenchmarkParameters() testUtils.IOBenchmarkParameters {
+	return testUtils.IOBenchmarkParameters{
+{{- with .Definition.IOBenchmark.FileSize}}
+		FileSize: d.quantity({{printf "%q" .String}}),
+{{- end}}
+{{- with .Definition.IOBenchmark.Runtime}}
+		Runtime: &metav1.Duration{Duration: {{$.Duration .Duration}}},
+{{- end}}
+{{- with .Definition.IOBenchmark.Thresholds}}
+		Thresholds: map[string]testUtils.IOBenchmarkThresholds{
+{{- range $workload, $thresholds := .}}
+			{{printf "%q" $workload}}: {
+{{- if $thresholds.MinIOPS}}
+				MinIOPS: {{printf "%v" $thresholds.MinIOPS}},
+{{- end}}
+{{- with $thresholds.MinBandwidth}}
+				MinBandwidth: d.quantity({{printf "%q" .String}}),
+{{- end}}
+{{- with $thresholds.MaxLatency}}
+				MaxLatency: &metav1.Duration{Duration: {{$.Duration .Duration}}},
+{{- end}}
+			},
+{{- end}}
+		},
+{{- end}}
+	}
+}
+{{- end}}
+{{- if .NeedsQuantity}}
+
+// quantity parses a quantity from the DriverDefinition.
+func (d *{{.Type}}) quantity(value string) *resource.Quantity {
+	q := resource.MustParse(value)
+	return &q
+}
+{{- end}}
+
+func (d *{{.Type}}) PrepareTest(f *framework.Framework) (*testsuites.PerTestConfig, func()) {
+	// TODO: deploy the driver or prepare the storage backend here,
+	// for example like the HostPath TestDriver does.
+	config := &testsuites.PerTestConfig{
+		Driver:         d,
+		Prefix:         {{printf "%q" .Name}},
+		Framework:      f,
+		ClientNodeName: {{printf "%q" .Definition.ClientNodeName}},
+	}
+	return config, func() {}
+}
+`))
@@ -0,0 +1,331 @@
+// Package scaffold generates the skeleton of a Go TestDriver in
+// pkg/certify/driver, modeled on the HostPath and NFS TestDrivers.
+package scaffold
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// Options selects what gets generated.
+type Options struct {
+	// Name is used for --testdriver, file names and Go identifiers.
+	// It must consist of lower case letters and digits.
+	Name string
+
+	// DriverName is the name of the CSI driver, for example
+	// "csi-hostpath".
+	DriverName string
+
+	// The optional TestDriver interfaces that the driver implements.
+	DynamicPV        bool
+	PreprovisionedPV bool
+	InlineVolume     bool
+	Snapshots        bool
+}
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// Validate checks the options and fills in defaults.
+func (o *Options) Validate() error {
+	if !validName.MatchString(o.Name) {
+		return errors.Errorf("invalid name %q: must start with a lower case letter and only contain lower case letters and digits", o.Name)
+	}
+	if o.DriverName == "" {
+		o.DriverName = "csi-" + o.Name
+	}
+	if o.Snapshots && !o.DynamicPV {
+		return errors.New("snapshots need dynamic provisioning")
+	}
+	return nil
+}
+
+// Files returns the content of the generated files, indexed by their
+// path relative to the root of the repo.
+func Files(o Options) (map[string][]byte, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	var source bytes.Buffer
+	if err := driverTemplate.Execute(&source, templateData{o}); err != nil {
+		return nil, errors.Wrap(err, "executing template")
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "formatting generated code:\n%s", source.String())
+	}
+
+	var readme bytes.Buffer
+	if err := manifestsTemplate.Execute(&readme, templateData{o}); err != nil {
+		return nil, errors.Wrap(err, "executing template")
+	}
+
+	return map[string][]byte{
+		filepath.Join("pkg", "certify", "driver", o.Name+"_driver.go"):              formatted,
+		filepath.Join("pkg", "certify", "driver", "manifests", o.Name, "README.md"): readme.Bytes(),
+	}, nil
+}
+
+// Write stores the files returned by Files or FilesFromDefinition below
+// the root of the repo. It refuses to overwrite existing files.
+func Write(root string, files map[string][]byte) ([]string, error) {
+	var paths []string
+	for path := range files {
+		if _, err := os.Stat(filepath.Join(root, path)); err == nil {
+			return nil, errors.Errorf("%s already exists", path)
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fullPath := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(fullPath, files[path], 0644); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+type templateData struct {
+	Options
+}
+
+// Type is the name of the Go type that implements the TestDriver.
+func (d templateData) Type() string {
+	return d.Name + "Driver"
+}
+
+// Init is the exported name of the TestDriver factory.
+func (d templateData) Init() string {
+	return "Init" + strings.Title(d.Name) + "Driver"
+}
+
+// InitUnexported is the name of the TestDriver constructor.
+func (d templateData) InitUnexported() string {
+	return "init" + strings.Title(d.Name) + "Driver"
+}
+
+// PreprovisionedVolume is true when CreateVolume is needed.
+func (d templateData) PreprovisionedVolume() bool {
+	return d.PreprovisionedPV || d.InlineVolume
+}
+
+var driverTemplate = template.Must(template.New("driver").Parse(`package driver
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+{{- if .PreprovisionedVolume}}
+	"k8s.io/api/core/v1"
+{{- end}}
+{{- if .DynamicPV}}
+	storagev1 "k8s.io/api/storage/v1"
+{{- end}}
+{{- if .Snapshots}}
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+{{- end}}
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+)
+
+func init() {
+	registry.Register("{{.Name}}", {{.Init}}, registry.Metadata{
+		Description: "TODO: describe the {{.DriverName}} TestDriver",
+		// TODO: set to true once the deployment gets patched so
+		// that tests can run in parallel.
+		ParallelSafe: false,
+	})
+}
+
+type {{.Type}} struct {
+	driverInfo testsuites.DriverInfo
+	manifests  []string
+}
+{{- if .PreprovisionedVolume}}
+
+type {{.Name}}Volume struct {
+	// TODO: add whatever identifies the volume.
+	f *framework.Framework
+}
+{{- end}}
+
+func {{.InitUnexported}}(name string, manifests ...string) testsuites.TestDriver {
+	return &{{.Type}}{
+		driverInfo: testsuites.DriverInfo{
+			Name:        name,
+			MaxFileSize: testpatterns.FileSizeMedium,
+			SupportedFsType: sets.NewString(
+				"", // Default fsType
+			),
+			// TODO: add the capabilities of the driver.
+			Capabilities: map[testsuites.Capability]bool{
+				testsuites.CapPersistence: true,
+			},
+		},
+		manifests: manifests,
+	}
+}
+
+// {{.Init}} returns {{.Type}} that implements TestDriver interface
+func {{.Init}}() testsuites.TestDriver {
+	// TODO: add the files from pkg/certify/driver/manifests/{{.Name}}.
+	return {{.InitUnexported}}("{{.DriverName}}")
+}
+
+var _ testsuites.TestDriver = &{{.Type}}{}
+{{- if .DynamicPV}}
+var _ testsuites.DynamicPVTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .PreprovisionedVolume}}
+var _ testsuites.PreprovisionedVolumeTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .PreprovisionedPV}}
+var _ testsuites.PreprovisionedPVTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .InlineVolume}}
+var _ testsuites.InlineVolumeTestDriver = &{{.Type}}{}
+{{- end}}
+{{- if .Snapshots}}
+var _ testsuites.SnapshottableTestDriver = &{{.Type}}{}
+{{- end}}
+
+func (d *{{.Type}}) GetDriverInfo() *testsuites.DriverInfo {
+	return &d.driverInfo
+}
+
+func (d *{{.Type}}) SkipUnsupportedTest(pattern testpatterns.TestPattern) {
+	// TODO: skip test patterns that the driver does not support
+	// and that are not covered by the DriverInfo.
+}
+
+func (d *{{.Type}}) PrepareTest(f *framework.Framework) (*testsuites.PerTestConfig, func()) {
+	By(fmt.Sprintf("deploying %s driver", d.driverInfo.Name))
+	cancel := testsuites.StartPodLogs(f)
+	config := &testsuites.PerTestConfig{
+		Driver:    d,
+		Prefix:    "{{.Name}}",
+		Framework: f,
+	}
+
+	o := utils.PatchCSIOptions{
+		OldDriverName: d.driverInfo.Name,
+		NewDriverName: config.GetUniqueDriverName(),
+		// TODO: set the container names of the deployment, then
+		// use config.GetUniqueDriverName() instead of
+		// d.driverInfo.Name everywhere below.
+	}
+	cleanup, err := config.Framework.CreateFromManifests(func(item interface{}) error {
+		if err := utils.PatchCSIDeployment(config.Framework, o, item); err != nil {
+			return err
+		}
+		return testUtils.PatchImages(item)
+	},
+		d.manifests...)
+	if err != nil {
+		framework.Failf("deploying %s driver: %v", d.driverInfo.Name, err)
+	}
+
+	return config, func() {
+		By(fmt.Sprintf("uninstalling %s driver", d.driverInfo.Name))
+		cleanup()
+		cancel()
+	}
+}
+{{- if .DynamicPV}}
+
+func (d *{{.Type}}) GetDynamicProvisionStorageClass(config *testsuites.PerTestConfig, fsType string) *storagev1.StorageClass {
+	provisioner := d.driverInfo.Name
+	// TODO: add the parameters that the driver needs.
+	parameters := map[string]string{}
+	if fsType != "" {
+		parameters["csi.storage.k8s.io/fstype"] = fsType
+	}
+	ns := config.Framework.Namespace.Name
+	suffix := fmt.Sprintf("%s-sc", provisioner)
+
+	return testsuites.GetStorageClass(provisioner, parameters, nil, ns, suffix)
+}
+
+func (d *{{.Type}}) GetClaimSize() string {
+	return "5Gi"
+}
+{{- end}}
+{{- if .Snapshots}}
+
+func (d *{{.Type}}) GetSnapshotClass(config *testsuites.PerTestConfig) *unstructured.Unstructured {
+	snapshotter := d.driverInfo.Name
+	parameters := map[string]string{}
+	ns := config.Framework.Namespace.Name
+	suffix := fmt.Sprintf("%s-vsc", snapshotter)
+
+	return testsuites.GetSnapshotClass(snapshotter, parameters, ns, suffix)
+}
+{{- end}}
+{{- if .PreprovisionedVolume}}
+
+func (d *{{.Type}}) CreateVolume(config *testsuites.PerTestConfig, volType testpatterns.TestVolType) testsuites.TestVolume {
+	// TODO: create a volume in the storage backend.
+	framework.Failf("%s: CreateVolume not implemented", d.driverInfo.Name)
+	return &{{.Name}}Volume{
+		f: config.Framework,
+	}
+}
+
+func (v *{{.Name}}Volume) DeleteVolume() {
+	// TODO: delete the volume in the storage backend.
+}
+{{- end}}
+{{- if .PreprovisionedPV}}
+
+func (d *{{.Type}}) GetPersistentVolumeSource(readOnly bool, fsType string, volume testsuites.TestVolume) (*v1.PersistentVolumeSource, *v1.VolumeNodeAffinity) {
+	// TODO: identify the volume with the fields of
+	// volume.(*{{.Name}}Volume).
+	return &v1.PersistentVolumeSource{
+		CSI: &v1.CSIPersistentVolumeSource{
+			Driver:           d.driverInfo.Name,
+			VolumeHandle:     "",
+			ReadOnly:         readOnly,
+			FSType:           fsType,
+			VolumeAttributes: map[string]string{},
+		},
+	}, nil
+}
+{{- end}}
+{{- if .InlineVolume}}
+
+func (d *{{.Type}}) GetVolumeSource(readOnly bool, fsType string, volume testsuites.TestVolume) *v1.VolumeSource {
+	// TODO: return a volume source that refers to
+	// volume.(*{{.Name}}Volume).
+	framework.Skipf("%s: inline volumes not implemented", d.driverInfo.Name)
+	return nil
+}
+{{- end}}
+`))
+
+var manifestsTemplate = template.Must(template.New("manifests").Parse(`Put the .yaml files that deploy {{.DriverName}} into this directory and
+list them in {{.Init}} in pkg/certify/driver/{{.Name}}_driver.go.
+
+The HostPath manifests in ../hostpath are a good example: one
+StatefulSet per sidecar, one DaemonSet for the node plugin and the RBAC
+rules that these need.
+`))
@@ -0,0 +1,282 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wongma7/csi-certify/pkg/certify/utils"
+)
+
+func TestFiles(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		o := Options{
+			Name:             "mydriver",
+			DynamicPV:        i&1 != 0,
+			PreprovisionedPV: i&2 != 0,
+			InlineVolume:     i&4 != 0,
+			Snapshots:        i&8 != 0,
+		}
+		t.Run(fmt.Sprintf("%+v", o), func(t *testing.T) {
+			files, err := Files(o)
+			if o.Snapshots && !o.DynamicPV {
+				if err == nil {
+					t.Fatal("expected error for snapshots without dynamic provisioning")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			source := string(files[filepath.Join("pkg", "certify", "driver", "mydriver_driver.go")])
+			for iface, expected := range map[string]bool{
+				"DynamicPVTestDriver":            o.DynamicPV,
+				"PreprovisionedVolumeTestDriver": o.PreprovisionedPV || o.InlineVolume,
+				"PreprovisionedPVTestDriver":     o.PreprovisionedPV,
+				"InlineVolumeTestDriver":         o.InlineVolume,
+				"SnapshottableTestDriver":        o.Snapshots,
+			} {
+				assertion := "var _ testsuites." + iface + " = &mydriverDriver{}"
+				if strings.Contains(source, assertion) != expected {
+					t.Errorf("expected %q to be present: %v\n%s", assertion, expected, source)
+				}
+			}
+			if !strings.Contains(source, `registry.Register("mydriver", InitMydriverDriver,`) {
+				t.Errorf("registration missing:\n%s", source)
+			}
+		})
+	}
+}
+
+func TestInvalidName(t *testing.T) {
+	for _, name := range []string{"", "MyDriver", "my-driver", "1driver"} {
+		if _, err := Files(Options{Name: name}); err == nil {
+			t.Errorf("expected error for name %q", name)
+		}
+	}
+}
+
+func TestFilesFromDefinition(t *testing.T) {
+	for _, test := range []struct {
+		file     string
+		expected []string
+		absent   []string
+	}{
+		{
+			file: "../external/driver-def.yaml",
+			expected: []string{
+				`Name:        "csi-hostpath",`,
+				`testsuites.CapDataSource:  true,`,
+				`var _ testsuites.DynamicPVTestDriver = &convertedDriver{}`,
+				`var _ testsuites.SnapshottableTestDriver = &convertedDriver{}`,
+				`return testsuites.GetStorageClass(provisioner, parameters, nil, ns, suffix)`,
+				`return "5Gi"`,
+			},
+			// Unset values need no implementation.
+			absent: []string{
+				"testUtils",
+				"GetNodePlugin",
+			},
+		},
+		{
+			file: "testdata/driver-def-full.yaml",
+			expected: []string{
+				`var _ testUtils.VolumeStatsTestDriver = &convertedDriver{}`,
+				`var _ testUtils.NodePluginTestDriver = &convertedDriver{}`,
+				`var _ testUtils.ControllerPluginTestDriver = &convertedDriver{}`,
+				`var _ testUtils.ScaleTestDriver = &convertedDriver{}`,
+				`var _ testUtils.IOBenchmarkTestDriver = &convertedDriver{}`,
+				`tolerances.UsedBytes = d.quantity("32Mi")`,
+				`capacityPercent := 5`,
+				`return testUtils.PodSelector{Namespace: "kube-system", LabelSelector: "app=csi-full-node"}`,
+				`return testUtils.PodSelector{Namespace: "", LabelSelector: "app=csi-full-controller"}`,
+				`Container: "driver", Command: "ls /volumes"}`,
+				`Count:       50,`,
+				`Concurrency: 5,`,
+				`FileSize: d.quantity("512Mi"),`,
+				`Runtime:  &metav1.Duration{Duration: 90 * time.Second},`,
+				`MinIOPS:    1500.5,`,
+				`MaxLatency: &metav1.Duration{Duration: 2500 * time.Microsecond},`,
+				`MinBandwidth: d.quantity("50Mi"),`,
+			},
+		},
+	} {
+		t.Run(test.file, func(t *testing.T) {
+			definition, err := utils.LoadDriverDefinition(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files, err := FilesFromDefinition("converted", definition)
+			if err != nil {
+				t.Fatal(err)
+			}
+			source := string(files[filepath.Join("pkg", "certify", "driver", "converted_driver.go")])
+			for _, expected := range test.expected {
+				if !strings.Contains(source, expected) {
+					t.Errorf("expected %q in generated code:\n%s", expected, source)
+				}
+			}
+			for _, absent := range test.absent {
+				if strings.Contains(source, absent) {
+					t.Errorf("unexpected %q in generated code:\n%s", absent, source)
+				}
+			}
+		})
+	}
+}
+
+// TestBuild writes generated TestDrivers into a copy of the repo in a
+// temporary GOPATH and builds them, because the generated code is
+// supposed to compile as-is. The TestDriver converted from a full
+// DriverDefinition must also return the same values as the
+// DriverDefinition.
+func TestBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building with the go tool is slow")
+	}
+	repo, err := filepath.Abs(filepath.Join("..", "..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gopath, err := ioutil.TempDir("", "csi-certify-scaffold-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(gopath)
+
+	// The driver package only contains the generated TestDrivers,
+	// everything else comes from the repo.
+	root := filepath.Join(gopath, "src", "github.com", "wongma7", "csi-certify")
+	if err := linkTree(repo, root, filepath.Join("pkg", "certify", "driver")); err != nil {
+		t.Fatal(err)
+	}
+	definition, err := utils.LoadDriverDefinition("../external/driver-def.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, err := utils.LoadDriverDefinition("testdata/driver-def-full.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, generate := range []func() (map[string][]byte, error){
+		func() (map[string][]byte, error) { return Files(Options{Name: "minimal"}) },
+		func() (map[string][]byte, error) {
+			return Files(Options{Name: "full", DynamicPV: true, PreprovisionedPV: true, InlineVolume: true, Snapshots: true})
+		},
+		func() (map[string][]byte, error) { return FilesFromDefinition("converted", definition) },
+		func() (map[string][]byte, error) { return FilesFromDefinition("fullconverted", full) },
+	} {
+		files, err := generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Write(root, files); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	env := append(os.Environ(), "GOPATH="+gopath, "GO111MODULE=off", "GOFLAGS=")
+	cmd := exec.Command("go", "build", "./pkg/certify/driver/")
+	cmd.Dir = root
+	cmd.Env = env
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	if err := os.Mkdir(filepath.Join(root, "roundtrip"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "roundtrip", "main.go"), []byte(roundTripProgram), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd = exec.Command("go", "run", "./roundtrip")
+	cmd.Dir = root
+	cmd.Env = env
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("go run: %v", err)
+	}
+	expected, err := json.Marshal(map[string]interface{}{
+		"VolumeStats":      full.VolumeStats,
+		"NodePlugin":       full.NodePlugin,
+		"ControllerPlugin": full.ControllerPlugin,
+		"VolumeLister":     full.VolumeLister,
+		"Scale":            full.Scale,
+		"IOBenchmark":      full.IOBenchmark,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := strings.TrimSpace(string(out)); actual != string(expected) {
+		t.Errorf("converted TestDriver returns different values than the DriverDefinition\nexpected: %s\nactual:   %s", expected, actual)
+	}
+}
+
+// roundTripProgram prints the values of the TestDriver converted from
+// testdata/driver-def-full.yaml like TestBuild prints the values of the
+// DriverDefinition.
+const roundTripProgram = `package main
+
+import (
+	"encoding/json"
+	"os"
+
+	_ "github.com/wongma7/csi-certify/pkg/certify/driver"
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
+	"github.com/wongma7/csi-certify/pkg/certify/utils"
+)
+
+func main() {
+	entry, ok := registry.Lookup("fullconverted")
+	if !ok {
+		panic("fullconverted not registered")
+	}
+	driver := entry.Factory()
+	controller := driver.(utils.ControllerPluginTestDriver)
+	if err := json.NewEncoder(os.Stdout).Encode(map[string]interface{}{
+		"VolumeStats":      driver.(utils.VolumeStatsTestDriver).GetVolumeStatsTolerances(),
+		"NodePlugin":       driver.(utils.NodePluginTestDriver).GetNodePlugin(nil),
+		"ControllerPlugin": controller.GetControllerPlugin(nil),
+		"VolumeLister":     controller.GetVolumeLister(nil),
+		"Scale":            driver.(utils.ScaleTestDriver).GetScaleParameters(),
+		"IOBenchmark":      driver.(utils.IOBenchmarkTestDriver).GetIOBenchmarkParameters(),
+	}); err != nil {
+		panic(err)
+	}
+}
+`
+
+// linkTree populates dst with symlinks to the content of src, except
+// for the directory skip, which gets created empty.
+func linkTree(src, dst, skip string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	entries, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		switch {
+		case entry.Name() == skip:
+			if err := os.Mkdir(filepath.Join(dst, skip), 0755); err != nil {
+				return err
+			}
+		case strings.HasPrefix(skip, entry.Name()+string(filepath.Separator)):
+			if err := linkTree(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name()), strings.TrimPrefix(skip, entry.Name()+string(filepath.Separator))); err != nil {
+				return err
+			}
+		default:
+			if err := os.Symlink(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Package selftest runs csi-certify against each variant of the mock
+// TestDriver and checks that the tests have the results which the
+// variant promises. It needs a cluster and is skipped without
+// --kubeconfig:
+//
+//	go test -v ./pkg/certify/selftest -timeout=0 --kubeconfig=/var/run/kubernetes/admin.kubeconfig
+package selftest
+
+import (
+	"encoding/xml"
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/wongma7/csi-certify/pkg/certify/driver"
+)
+
+var kubeconfig = flag.String("kubeconfig", "", "the kubeconfig of the cluster which the mock driver gets deployed to")
+
+// driverContext is the ginkgo context of the tests for the mock
+// driver, see runTestForDriver in pkg/certify/test.
+const driverContext = "[Driver: csi-mock] "
+
+func TestMockDrivers(t *testing.T) {
+	if *kubeconfig == "" {
+		t.Skip("no --kubeconfig given")
+	}
+
+	tmp, err := ioutil.TempDir("", "csi-certify-selftest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	binary := filepath.Join(tmp, "certify.test")
+	if out, err := exec.Command("go", "test", "-c", "-o", binary, "github.com/wongma7/csi-certify/cmd/certify").CombinedOutput(); err != nil {
+		t.Fatalf("building csi-certify: %v\n%s", err, out)
+	}
+
+	expectations := driver.MockExpectations()
+	var names []string
+	for name := range expectations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			results := runMockDriver(t, binary, name, expectations[name], filepath.Join(tmp, name))
+			for _, expected := range expectations[name] {
+				testCase, ok := results[expected.Test]
+				if !ok {
+					t.Errorf("%s: no result", expected.Test)
+					continue
+				}
+				if result := resultOf(testCase); result != expected.Result {
+					t.Errorf("%s: expected %s, got %s", expected.Test, expected.Result, result)
+				}
+				if duration := time.Duration(testCase.Time * float64(time.Second)); duration < expected.MinDuration {
+					t.Errorf("%s: expected to take at least %s, took %s", expected.Test, expected.MinDuration, duration)
+				}
+			}
+		})
+	}
+}
+
+// runMockDriver runs the tests in the expectations against one variant
+// of the mock driver and returns the JUnit test cases by test name.
+func runMockDriver(t *testing.T, binary, name string, expectations []driver.MockExpectation, reportDir string) map[string]reporters.JUnitTestCase {
+	var focus []string
+	for _, expected := range expectations {
+		focus = append(focus, regexp.QuoteMeta(driverContext+expected.Test))
+	}
+
+	// Failing tests make the binary fail, therefore only the JUnit
+	// report tells whether the run worked.
+	cmd := exec.Command(binary,
+		"--kubeconfig="+*kubeconfig,
+		"--testdriver="+name,
+		"--report-dir="+reportDir,
+		"--ginkgo.focus="+strings.Join(focus, "|"),
+	)
+	// The manifests are found relative to the working directory.
+	cmd.Dir = "../../../cmd/certify"
+	out, err := cmd.CombinedOutput()
+	t.Logf("csi-certify --testdriver=%s: %v\n%s", name, err, out)
+
+	data, err := ioutil.ReadFile(filepath.Join(reportDir, "junit_01.xml"))
+	if err != nil {
+		t.Fatalf("reading JUnit report: %v", err)
+	}
+	var suite reporters.JUnitTestSuite
+	if err := xml.Unmarshal(data, &suite); err != nil {
+		t.Fatalf("parsing JUnit report: %v", err)
+	}
+
+	results := map[string]reporters.JUnitTestCase{}
+	for _, testCase := range suite.TestCases {
+		i := strings.Index(testCase.Name, driverContext)
+		if i < 0 {
+			continue
+		}
+		results[testCase.Name[i+len(driverContext):]] = testCase
+	}
+	return results
+}
+
+func resultOf(testCase reporters.JUnitTestCase) driver.MockResult {
+	switch {
+	case testCase.FailureMessage != nil:
+		return driver.MockFailed
+	case testCase.Skipped != nil:
+		return driver.MockSkipped
+	default:
+		return driver.MockPassed
+	}
+}
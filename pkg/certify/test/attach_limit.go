@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/pkg/volume/util"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+const (
+	// maxTestedAttachLimit avoids creating hundreds of volumes for
+	// drivers which report a large limit.
+	maxTestedAttachLimit = 32
+
+	// attachLimitTimeout is how long kubelet may take to publish the
+	// limit after the driver got registered.
+	attachLimitTimeout = 2 * time.Minute
+
+	// maxVolumeCountMessage is what the scheduler reports for pods
+	// which do not fit onto a node because of the limit.
+	maxVolumeCountMessage = "exceed max volume count"
+)
+
+type attachLimitTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &attachLimitTestSuite{}
+
+// InitAttachLimitTestSuite returns attachLimitTestSuite that implements TestSuite interface
+func InitAttachLimitTestSuite() TestSuite {
+	return &attachLimitTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name: "attach limit",
+			// The test fills up a node, other tests would get in the way.
+			FeatureTag: " [Serial]",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *attachLimitTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *attachLimitTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		cs   clientset.Interface
+		ns   *v1.Namespace
+		sc   *storagev1.StorageClass
+		pvcs []*v1.PersistentVolumeClaim
+		pods []*v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		if !dInfo.Capabilities[testUtils.CapAttachLimit] {
+			framework.Skipf("Driver %q does not report an attach limit - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("attachlimit")
+
+	init := func() {
+		l = local{}
+		l.ns = f.Namespace
+		l.cs = f.ClientSet
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+
+		dDriver, _ := driver.(testsuites.DynamicPVTestDriver)
+		l.sc = dDriver.GetDynamicProvisionStorageClass(l.config, pattern.FsType)
+		if l.sc == nil {
+			framework.Skipf("Driver %q does not define Dynamic Provision StorageClass - skipping", dInfo.Name)
+		}
+	}
+
+	cleanup := func() {
+		for _, pod := range l.pods {
+			By("Deleting pod " + pod.Name)
+			if err := framework.DeletePodWithWait(f, l.cs, pod); err != nil {
+				framework.Logf("Failed to delete pod %s: %v", pod.Name, err)
+			}
+		}
+		l.pods = nil
+
+		for _, pvc := range l.pvcs {
+			By("Deleting pvc " + pvc.Name)
+			if err := framework.DeletePersistentVolumeClaim(l.cs, pvc.Name, pvc.Namespace); err != nil {
+				framework.Logf("Failed to delete PVC %s: %v", pvc.Name, err)
+			}
+		}
+		l.pvcs = nil
+
+		if l.sc != nil {
+			By("Deleting sc")
+			deleteStorageClass(l.cs, l.sc.Name)
+			l.sc = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+	}
+
+	// createClaims creates and binds the given number of PVCs.
+	createClaims := func(count int) []*v1.PersistentVolumeClaim {
+		claimSize := driver.(testsuites.DynamicPVTestDriver).GetClaimSize()
+		var claims []*v1.PersistentVolumeClaim
+		for i := 0; i < count; i++ {
+			pvc := getClaim(claimSize, l.ns.Name)
+			pvc.Spec.StorageClassName = &l.sc.Name
+			pvc, err := framework.CreatePVC(l.cs, l.ns.Name, pvc)
+			framework.ExpectNoError(err, "create PVC")
+			l.pvcs = append(l.pvcs, pvc)
+			claims = append(claims, pvc)
+		}
+		for _, pvc := range claims {
+			err := framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, l.cs, pvc.Namespace, pvc.Name, framework.Poll, framework.ClaimProvisionTimeout)
+			framework.ExpectNoError(err, "PVC %s not bound", pvc.Name)
+		}
+		return claims
+	}
+
+	// createPod creates a pod which can only run on the given node. A
+	// node selector is used instead of the node name so that the
+	// scheduler checks the limit.
+	createPod := func(node *v1.Node, claims []*v1.PersistentVolumeClaim) *v1.Pod {
+		nodeSelector := map[string]string{"kubernetes.io/hostname": node.Labels["kubernetes.io/hostname"]}
+		pod := framework.MakePod(l.ns.Name, nodeSelector, claims, false, "")
+		pod, err := l.cs.CoreV1().Pods(l.ns.Name).Create(pod)
+		framework.ExpectNoError(err, "create pod")
+		l.pods = append(l.pods, pod)
+		return pod
+	}
+
+	It("should not schedule more volumes onto a node than the driver allows", func() {
+		init()
+		defer cleanup()
+
+		var err error
+
+		By("Creating sc")
+		l.sc, err = l.cs.StorageV1().StorageClasses().Create(l.sc)
+		Expect(err).NotTo(HaveOccurred())
+
+		node := getTestNode(l.cs, l.config)
+		limitKey := v1.ResourceName(util.GetCSIAttachLimitKey(l.sc.Provisioner))
+		limit := waitForAttachLimit(l.cs, node.Name, limitKey)
+		if limit > maxTestedAttachLimit {
+			framework.Skipf("Attach limit %d of node %s is larger than %d - skipping", limit, node.Name, maxTestedAttachLimit)
+		}
+
+		By("Filling node " + node.Name + " up to the limit")
+		pod := createPod(node, createClaims(limit))
+		framework.ExpectNoError(framework.WaitForPodNameRunningInNamespace(l.cs, pod.Name, pod.Namespace))
+
+		By("Checking that one more volume does not fit")
+		pod = createPod(node, createClaims(1))
+		err = framework.WaitForPodCondition(l.cs, pod.Namespace, pod.Name, "unschedulable", framework.PodStartShortTimeout, func(pod *v1.Pod) (bool, error) {
+			if pod.Spec.NodeName != "" {
+				framework.Failf("Pod %s got scheduled onto node %s", pod.Name, pod.Spec.NodeName)
+			}
+			for _, condition := range pod.Status.Conditions {
+				if condition.Type == v1.PodScheduled &&
+					condition.Status == v1.ConditionFalse &&
+					condition.Reason == v1.PodReasonUnschedulable &&
+					strings.Contains(condition.Message, maxVolumeCountMessage) {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		framework.ExpectNoError(err, "pod %s did not become unschedulable because of the attach limit", pod.Name)
+		pod, err = l.cs.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+		framework.ExpectNoError(err, "get pod")
+		Expect(pod.Status.Phase).To(Equal(v1.PodPending), "pod %s phase", pod.Name)
+	})
+}
+
+// getTestNode returns the node selected by the driver, or any
+// schedulable node if the driver doesn't care.
+func getTestNode(cs clientset.Interface, config *testsuites.PerTestConfig) *v1.Node {
+	if config.ClientNodeName != "" {
+		node, err := cs.CoreV1().Nodes().Get(config.ClientNodeName, metav1.GetOptions{})
+		framework.ExpectNoError(err, "get node %s", config.ClientNodeName)
+		return node
+	}
+	nodes := framework.GetReadySchedulableNodesOrDie(cs)
+	Expect(nodes.Items).NotTo(BeEmpty(), "schedulable nodes")
+	return &nodes.Items[0]
+}
+
+// waitForAttachLimit returns the number of volumes that the node
+// accepts for the driver. This Kubernetes release has no CSINode
+// object yet, instead kubelet copies max_volumes_per_node from
+// NodeGetInfo into the node's allocatable resources.
+func waitForAttachLimit(cs clientset.Interface, nodeName string, limitKey v1.ResourceName) int {
+	var limit int64
+	err := wait.PollImmediate(framework.Poll, attachLimitTimeout, func() (bool, error) {
+		node, err := cs.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		quantity, ok := node.Status.Allocatable[limitKey]
+		if !ok {
+			return false, nil
+		}
+		limit = quantity.Value()
+		return true, nil
+	})
+	framework.ExpectNoError(err, "node %s does not report %s", nodeName, limitKey)
+	Expect(limit).To(BeNumerically(">", 0), "%s of node %s", limitKey, nodeName)
+	return int(limit)
+}
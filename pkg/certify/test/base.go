@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+// TestSuite is the counterpart of testsuites.TestSuite for the test
+// suites defined by csi-certify. The upstream interface cannot be
+// implemented outside of its package because its methods are not
+// exported.
+type TestSuite interface {
+	// GetTestSuiteInfo returns the TestSuiteInfo for this TestSuite
+	GetTestSuiteInfo() TestSuiteInfo
+	// DefineTests defines tests of the testpattern for the driver.
+	// Called inside a Ginkgo context that reflects the current driver and test pattern,
+	// so the test suite can define tests directly with ginkgo.It.
+	DefineTests(testsuites.TestDriver, testpatterns.TestPattern)
+}
+
+// TestSuiteInfo represents a set of parameters for TestSuite
+type TestSuiteInfo struct {
+	Name         string                     // name of the TestSuite
+	FeatureTag   string                     // featureTag for the TestSuite
+	TestPatterns []testpatterns.TestPattern // Slice of TestPattern for the TestSuite
+}
+
+// List of csi-certify testSuites to be executed in addition to
+// testUtils.CSITestSuites
+var CertifyTestSuites = []func() TestSuite{
+	InitAttachLimitTestSuite,
+	InitVolumeStatsTestSuite,
+	InitReadWriteManyTestSuite,
+	InitReadOnlyTestSuite,
+	InitNodePluginRestartTestSuite,
+	InitControllerRestartTestSuite,
+	InitCloningTestSuite,
+	InitSnapshotIntegrityTestSuite,
+	InitScaleTestSuite,
+	InitIOBenchmarkTestSuite,
+	InitMountOptionsTestSuite,
+}
+
+// DefineTestSuites defines all tests for a driver, both those from
+// testUtils.CSITestSuites and those from CertifyTestSuites.
+func DefineTestSuites(driver testsuites.TestDriver) {
+	testsuites.DefineTestSuite(driver, testUtils.CSITestSuites)
+	DefineTestSuite(driver, CertifyTestSuites)
+}
+
+// DefineTestSuite defines tests for all testpatterns and all testSuites for a driver
+func DefineTestSuite(driver testsuites.TestDriver, tsInits []func() TestSuite) {
+	for _, testSuiteInit := range tsInits {
+		suite := testSuiteInit()
+		for _, pattern := range suite.GetTestSuiteInfo().TestPatterns {
+			p := pattern
+			Context(getTestNameStr(suite, p), func() {
+				BeforeEach(func() {
+					// Skip unsupported tests to avoid unnecessary resource initialization
+					testUtils.SkipUnsupportedTest(driver, p)
+				})
+				suite.DefineTests(driver, p)
+			})
+		}
+	}
+}
+
+func getTestNameStr(suite TestSuite, pattern testpatterns.TestPattern) string {
+	tsInfo := suite.GetTestSuiteInfo()
+	return fmt.Sprintf("[Testpattern: %s]%s %s%s", pattern.Name, pattern.FeatureTag, tsInfo.Name, tsInfo.FeatureTag)
+}
+
+func getClaim(claimSize string, ns string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "pvc-",
+			Namespace:    ns,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{
+				v1.ReadWriteOnce,
+			},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceName(v1.ResourceStorage): resource.MustParse(claimSize),
+				},
+			},
+		},
+	}
+}
+
+func deleteStorageClass(cs clientset.Interface, className string) {
+	err := cs.StorageV1().StorageClasses().Delete(className, nil)
+	if err != nil {
+		framework.Logf("Failed to delete StorageClass %s: %v", className, err)
+	}
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	. "github.com/onsi/ginkgo"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+// cloneDataCommand generates the data pattern which gets written into
+// the source volume and compared with the clone.
+const cloneDataCommand = "seq 1 100000"
+
+type cloningTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &cloningTestSuite{}
+
+// InitCloningTestSuite returns cloningTestSuite that implements TestSuite interface
+func InitCloningTestSuite() TestSuite {
+	return &cloningTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name: "cloning",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *cloningTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *cloningTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		resource *volumeResource
+		clonePVC *v1.PersistentVolumeClaim
+		pods     []*v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		if !dInfo.Capabilities[testUtils.CapCloning] {
+			framework.Skipf("Driver %q does not support cloning - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("cloning")
+
+	// init provisions the source volume and writes the data pattern
+	// into it.
+	init := func() {
+		l = local{}
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+		l.resource = createVolumeResource(driver, l.config, pattern, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, false)
+
+		By("Writing data")
+		pod := createPodOnNode(f, l.resource.pvc, l.config.ClientNodeName, false)
+		defer func() {
+			framework.ExpectNoError(framework.DeletePodWithWait(f, f.ClientSet, pod), "delete pod %s", pod.Name)
+		}()
+		utils.VerifyExecInPodSucceed(pod, cloneDataCommand+" >/mnt/volume1/data && sync")
+	}
+
+	cleanup := func() {
+		var errs []error
+		for _, pod := range l.pods {
+			By("Deleting pod " + pod.Name)
+			errs = append(errs, framework.DeletePodWithWait(f, f.ClientSet, pod))
+		}
+		l.pods = nil
+
+		// The clone must be gone before the source gets deleted,
+		// some storage systems refuse to delete volumes which
+		// still have clones.
+		if l.clonePVC != nil {
+			By("Deleting clone pvc")
+			errs = append(errs, deleteClaim(f.ClientSet, l.clonePVC))
+			l.clonePVC = nil
+		}
+
+		if l.resource != nil {
+			l.resource.cleanupResource()
+			l.resource = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+		framework.ExpectNoError(utilerrors.NewAggregate(errs), "while cleaning up after test")
+	}
+
+	// createClone creates a PVC with the source PVC as dataSource
+	// and the given size.
+	createClone := func(size resource.Quantity) {
+		source := l.resource.pvc
+		l.clonePVC = createClaimFromDataSource(f.ClientSet, source.Namespace, source.Spec.StorageClassName, size, &v1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: source.Name,
+		})
+	}
+
+	// checkClone verifies the content of the clone and then that
+	// writes into the clone and the source don't affect each other.
+	checkClone := func() {
+		By("Checking the data in the clone")
+		clonePod := createPodOnNode(f, l.clonePVC, l.config.ClientNodeName, false)
+		l.pods = append(l.pods, clonePod)
+		utils.VerifyExecInPodSucceed(clonePod, cloneDataCommand+" | cmp - /mnt/volume1/data")
+
+		By("Writing into the clone")
+		utils.VerifyExecInPodSucceed(clonePod, "echo clone >/mnt/volume1/data && echo clone >/mnt/volume1/marker && sync")
+
+		By("Checking that the source is unchanged")
+		sourcePod := createPodOnNode(f, l.resource.pvc, l.config.ClientNodeName, false)
+		l.pods = append(l.pods, sourcePod)
+		utils.VerifyExecInPodSucceed(sourcePod, cloneDataCommand+" | cmp - /mnt/volume1/data && test ! -e /mnt/volume1/marker")
+
+		By("Writing into the source")
+		utils.VerifyExecInPodSucceed(sourcePod, "echo source >/mnt/volume1/marker && sync")
+
+		By("Checking that the clone is unchanged")
+		utils.VerifyExecInPodSucceed(clonePod, "grep -x clone /mnt/volume1/data && grep -x clone /mnt/volume1/marker")
+	}
+
+	It("should clone a volume into a volume of the same size", func() {
+		init()
+		defer cleanup()
+
+		createClone(l.resource.pvc.Spec.Resources.Requests[v1.ResourceStorage])
+		checkClone()
+	})
+
+	It("should clone a volume into a larger volume", func() {
+		init()
+		defer cleanup()
+
+		size := l.resource.pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		size.Add(resource.MustParse(largerVolumeExtraSize))
+		createClone(size)
+		checkClone()
+	})
+}
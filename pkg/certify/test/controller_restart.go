@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+// leakCheckDuration is how long the test waits for additional PVs
+// which would be created when CreateVolume is not idempotent.
+const leakCheckDuration = 30 * time.Second
+
+type controllerRestartTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &controllerRestartTestSuite{}
+
+// InitControllerRestartTestSuite returns controllerRestartTestSuite that implements TestSuite interface
+func InitControllerRestartTestSuite() TestSuite {
+	return &controllerRestartTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name:       "controller restart",
+			FeatureTag: " [Serial] [Disruptive]",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *controllerRestartTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *controllerRestartTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		cs           clientset.Interface
+		controller   testUtils.PodSelector
+		volumeLister testUtils.PodCommand
+		sc           *storagev1.StorageClass
+		pvc          *v1.PersistentVolumeClaim
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		if _, ok := driver.(testUtils.ControllerPluginTestDriver); !ok {
+			framework.Skipf("Driver %q does not identify its controller - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("controllerrestart")
+
+	init := func() {
+		l = local{}
+		l.cs = f.ClientSet
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+		l.controller = getPodSelector(driver.(testUtils.ControllerPluginTestDriver).GetControllerPlugin(l.config), f)
+		if l.controller.LabelSelector == "" {
+			framework.Skipf("Driver %q does not identify its controller - skipping", dInfo.Name)
+		}
+		l.volumeLister = driver.(testUtils.ControllerPluginTestDriver).GetVolumeLister(l.config)
+		l.volumeLister.Pods = getPodSelector(l.volumeLister.Pods, f)
+
+		dDriver, _ := driver.(testsuites.DynamicPVTestDriver)
+		sc := dDriver.GetDynamicProvisionStorageClass(l.config, pattern.FsType)
+		if sc == nil {
+			framework.Skipf("Driver %q does not define Dynamic Provision StorageClass - skipping", dInfo.Name)
+		}
+		var err error
+		By("Creating sc")
+		l.sc, err = l.cs.StorageV1().StorageClasses().Create(sc)
+		Expect(err).NotTo(HaveOccurred())
+		l.pvc = getClaim(dDriver.GetClaimSize(), f.Namespace.Name)
+		l.pvc.Spec.StorageClassName = &l.sc.Name
+	}
+
+	cleanup := func() {
+		if l.pvc != nil && l.pvc.Name != "" {
+			By("Deleting pvc")
+			if err := framework.DeletePersistentVolumeClaim(l.cs, l.pvc.Name, l.pvc.Namespace); err != nil {
+				framework.Logf("Failed to delete PVC %s: %v", l.pvc.Name, err)
+			}
+		}
+		l.pvc = nil
+
+		if l.sc != nil {
+			// PVs that are still around at this point are leaks
+			// which get reported by the tests, but they should not
+			// affect other tests.
+			for _, pv := range listPVs(l.cs, l.sc.Name) {
+				framework.Logf("Waiting for PV %s to be deleted", pv.Name)
+				framework.WaitForPersistentVolumeDeleted(l.cs, pv.Name, framework.Poll, framework.PVDeletingTimeout)
+			}
+			By("Deleting sc")
+			deleteStorageClass(l.cs, l.sc.Name)
+			l.sc = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+	}
+
+	// expectPVs checks that the number of PVs for the storage class
+	// doesn't change from count for a while.
+	expectPVs := func(count int) {
+		Consistently(func() []string {
+			var names []string
+			for _, pv := range listPVs(l.cs, l.sc.Name) {
+				names = append(names, pv.Name)
+			}
+			return names
+		}, leakCheckDuration, framework.Poll).Should(HaveLen(count), "PVs of storage class %s", l.sc.Name)
+	}
+
+	// listBackendVolumes returns the volumes in the storage backend
+	// or nil if the driver cannot list them.
+	listBackendVolumes := func() sets.String {
+		if l.volumeLister.Command == "" {
+			return nil
+		}
+		return sets.NewString(runPodCommand(l.cs, l.volumeLister)...)
+	}
+
+	// expectBackendVolumes checks that count volumes were added to
+	// the storage backend since baseline was listed.
+	expectBackendVolumes := func(baseline sets.String, count int) {
+		if baseline == nil {
+			framework.Logf("Driver %q does not list its volumes, only PVs were checked", dInfo.Name)
+			return
+		}
+		By("Checking the volumes in the storage backend")
+		added := listBackendVolumes().Difference(baseline)
+		Expect(added.List()).To(HaveLen(count), "new volumes in the storage backend")
+	}
+
+	It("should provision exactly one volume when the controller restarts during CreateVolume", func() {
+		init()
+		defer cleanup()
+
+		baseline := listBackendVolumes()
+		var err error
+		By("Creating pvc")
+		l.pvc, err = framework.CreatePVC(l.cs, f.Namespace.Name, l.pvc)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The provisioner emits this event right before calling
+		// CreateVolume. Whether the call is still running when
+		// the controller gets restarted depends on how long the
+		// driver takes for it.
+		By("Waiting for the provisioner to call CreateVolume")
+		waitForProvisioningEvent(l.cs, l.pvc)
+		By("Restarting the controller while provisioning")
+		restartPods(l.cs, l.controller, "")
+
+		err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, l.cs, l.pvc.Namespace, l.pvc.Name, framework.Poll, framework.ClaimProvisionTimeout)
+		Expect(err).NotTo(HaveOccurred(), "PVC %s not bound after restart", l.pvc.Name)
+		l.pvc, err = l.cs.CoreV1().PersistentVolumeClaims(l.pvc.Namespace).Get(l.pvc.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Checking that no other PV got provisioned")
+		expectPVs(1)
+		pv := listPVs(l.cs, l.sc.Name)[0]
+		Expect(pv.Name).To(Equal(l.pvc.Spec.VolumeName), "PV of PVC %s", l.pvc.Name)
+		expectBackendVolumes(baseline, 1)
+	})
+
+	It("should delete the volume when the controller restarts during DeleteVolume", func() {
+		init()
+		defer cleanup()
+
+		baseline := listBackendVolumes()
+		var err error
+		By("Creating pvc")
+		l.pvc, err = framework.CreatePVC(l.cs, f.Namespace.Name, l.pvc)
+		Expect(err).NotTo(HaveOccurred())
+		err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, l.cs, l.pvc.Namespace, l.pvc.Name, framework.Poll, framework.ClaimProvisionTimeout)
+		Expect(err).NotTo(HaveOccurred())
+		l.pvc, err = l.cs.CoreV1().PersistentVolumeClaims(l.pvc.Namespace).Get(l.pvc.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		pvName := l.pvc.Spec.VolumeName
+		// Also verifies that the volume lister finds new volumes.
+		expectBackendVolumes(baseline, 1)
+
+		By("Deleting pvc")
+		err = framework.DeletePersistentVolumeClaim(l.cs, l.pvc.Name, l.pvc.Namespace)
+		Expect(err).NotTo(HaveOccurred())
+		l.pvc = nil
+
+		// There is no event before DeleteVolume, so this is
+		// best-effort: fast drivers may be done already.
+		By("Restarting the controller while deleting")
+		restartPods(l.cs, l.controller, "")
+
+		err = framework.WaitForPersistentVolumeDeleted(l.cs, pvName, framework.Poll, framework.PVDeletingTimeout)
+		Expect(err).NotTo(HaveOccurred(), "PV %s not deleted after restart", pvName)
+		By("Checking that no PV is left")
+		expectPVs(0)
+		expectBackendVolumes(baseline, 0)
+	})
+}
+
+// waitForProvisioningEvent waits until the external provisioner has
+// started to provision a volume for the PVC.
+func waitForProvisioningEvent(cs clientset.Interface, pvc *v1.PersistentVolumeClaim) {
+	selector := fields.Set{
+		"involvedObject.kind": "PersistentVolumeClaim",
+		"involvedObject.name": pvc.Name,
+		"reason":              "Provisioning",
+	}.AsSelector().String()
+	err := wait.PollImmediate(time.Second, framework.ClaimProvisionTimeout, func() (bool, error) {
+		events, err := cs.CoreV1().Events(pvc.Namespace).List(metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			framework.Logf("Failed to list events of PVC %s: %v", pvc.Name, err)
+			return false, nil
+		}
+		return len(events.Items) > 0, nil
+	})
+	framework.ExpectNoError(err, "Provisioning event for PVC %s", pvc.Name)
+}
+
+// listPVs returns the PVs of a storage class.
+func listPVs(cs clientset.Interface, className string) []v1.PersistentVolume {
+	pvs, err := cs.CoreV1().PersistentVolumes().List(metav1.ListOptions{})
+	framework.ExpectNoError(err, "list PVs")
+	var result []v1.PersistentVolume
+	for _, pv := range pvs.Items {
+		if pv.Spec.StorageClassName == className {
+			result = append(result, pv)
+		}
+	}
+	return result
+}
@@ -17,40 +17,50 @@ limitations under the License.
 package storage
 
 import (
+	"path"
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	_ "github.com/onsi/gomega"
-	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"github.com/pkg/errors"
+	"github.com/wongma7/csi-certify/pkg/certify/registry"
 	"k8s.io/kubernetes/test/e2e/framework"
 	"k8s.io/kubernetes/test/e2e/framework/testfiles"
 	"k8s.io/kubernetes/test/e2e/storage/testsuites"
 	"k8s.io/kubernetes/test/e2e/storage/utils"
-	"path"
 )
 
-// This executes testSuites for csi volumes.
-func RunCustomTestDriver(customTestDriver string) {
+// This executes testSuites for csi volumes. customTestDriver selects
+// one of the registered TestDrivers, all of them except for the opt-in
+// ones are used if it is empty.
+func RunCustomTestDriver(customTestDriver string) error {
+	var entries []registry.Entry
+	for _, entry := range registry.Entries() {
+		if !entry.OptIn {
+			entries = append(entries, entry)
+		}
+	}
+	if customTestDriver != "" {
+		entry, ok := registry.Lookup(customTestDriver)
+		if !ok {
+			return errors.Errorf("unknown TestDriver %q, available: %s", customTestDriver, strings.Join(registry.Names(), ", "))
+		}
+		entries = []registry.Entry{entry}
+	}
+
 	var _ = utils.SIGDescribe("CSI Volumes", func() {
 		testfiles.AddFileSource(testfiles.RootFileSource{Root: path.Join(framework.TestContext.RepoRoot, "./pkg/certify/driver/manifests")})
 
-		if customTestDriver == "" {
-			//if a specific testDriver is not chosen, run for all testDriver implementations
-			for _, driver := range testUtils.CSITestDrivers {
-				runTestForDriver(driver())
-			}
-		} else {
-			if testUtils.CSITestDrivers[customTestDriver] == nil {
-				framework.Failf("Given TestDriver %s, does not exist", customTestDriver)
-			}
-
-			runTestForDriver(testUtils.CSITestDrivers[customTestDriver]())
+		for _, entry := range entries {
+			runTestForDriver(entry.Factory(), entry.Tags())
 		}
-
 	})
 
+	return nil
 }
 
-func runTestForDriver(driver testsuites.TestDriver) {
-	Context(testsuites.GetDriverNameWithFeatureTags(driver), func() {
-		testsuites.DefineTestSuite(driver, testUtils.CSITestSuites)
+func runTestForDriver(driver testsuites.TestDriver, tags string) {
+	Context(testsuites.GetDriverNameWithFeatureTags(driver)+tags, func() {
+		DefineTestSuites(driver)
 	})
 }
@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/pkg/errors"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+const (
+	defaultIOBenchmarkFileSize = 1 * framework.GiB
+	defaultIOBenchmarkRuntime  = 30 * time.Second
+)
+
+// ioBenchmarkWorkload is a fio job definition.
+type ioBenchmarkWorkload struct {
+	name string
+	// rw and bs are the fio parameters of the same name.
+	rw, bs string
+	// write selects which part of the fio results is relevant.
+	write bool
+}
+
+// ioBenchmarkWorkloads run in this order, so the reads find a file
+// that was completely written.
+var ioBenchmarkWorkloads = []ioBenchmarkWorkload{
+	{name: testUtils.IOBenchmarkSequentialWrite, rw: "write", bs: "1M", write: true},
+	{name: testUtils.IOBenchmarkSequentialRead, rw: "read", bs: "1M"},
+	{name: testUtils.IOBenchmarkRandomWrite, rw: "randwrite", bs: "4k", write: true},
+	{name: testUtils.IOBenchmarkRandomRead, rw: "randread", bs: "4k"},
+}
+
+type ioBenchmarkTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &ioBenchmarkTestSuite{}
+
+// InitIOBenchmarkTestSuite returns ioBenchmarkTestSuite that implements TestSuite interface
+func InitIOBenchmarkTestSuite() TestSuite {
+	return &ioBenchmarkTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name:       "IO benchmark",
+			FeatureTag: " [Serial] [Slow] [Feature:IOBenchmark]",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *ioBenchmarkTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *ioBenchmarkTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		params    testUtils.IOBenchmarkParameters
+		claimSize string
+		resource  *volumeResource
+		pod       *v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	// Beware that this registers an AfterEach which renders f
+	// unusable. Any code using f must run inside an It or Context
+	// callback.
+	f := framework.NewDefaultFramework("iobenchmark")
+
+	init := func() {
+		l = local{}
+		if dDriver, ok := driver.(testsuites.DynamicPVTestDriver); ok {
+			l.claimSize = dDriver.GetClaimSize()
+		}
+		if bDriver, ok := driver.(testUtils.IOBenchmarkTestDriver); ok {
+			l.params = bDriver.GetIOBenchmarkParameters()
+			framework.ExpectNoError(l.params.Validate(l.claimSize), "IO benchmark parameters of driver %q", dInfo.Name)
+		}
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+		l.resource = createVolumeResource(driver, l.config, pattern, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, false)
+
+		By("Starting fio pod")
+		pod := framework.MakeSecPod(f.Namespace.Name, []*v1.PersistentVolumeClaim{l.resource.pvc}, false, "", false, false, framework.SELinuxLabel, nil)
+		pod.Spec.NodeName = l.config.ClientNodeName
+		container := &pod.Spec.Containers[0]
+		container.Name = "fio"
+		container.Image = testUtils.RewriteImage(container.Name, testUtils.FioImage)
+		l.pod = runPod(f, pod)
+	}
+
+	cleanup := func() {
+		if l.pod != nil {
+			By("Deleting pod")
+			framework.ExpectNoError(framework.DeletePodWithWait(f, f.ClientSet, l.pod), "delete pod %s", l.pod.Name)
+			l.pod = nil
+		}
+
+		if l.resource != nil {
+			l.resource.cleanupResource()
+			l.resource = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+	}
+
+	It("should meet the minimum performance for sequential and random IO", func() {
+		init()
+		defer cleanup()
+
+		fileSize, err := ioBenchmarkFileSize(l.params, dInfo.MaxFileSize, l.claimSize)
+		framework.ExpectNoError(err, "IO benchmark file size of driver %q", dInfo.Name)
+		runtime := defaultIOBenchmarkRuntime
+		if l.params.Runtime != nil {
+			runtime = l.params.Runtime.Duration
+		}
+
+		summary := &ioBenchmarkSummary{
+			Driver:   dInfo.Name,
+			FileSize: fileSize,
+			Runtime:  runtime,
+		}
+		for _, workload := range ioBenchmarkWorkloads {
+			By("Running fio workload " + workload.name)
+			cmd := fmt.Sprintf("fio --name=%s --filename=/mnt/volume1/fio-data --size=%d --rw=%s --bs=%s --direct=1 --runtime=%d --time_based --output-format=json",
+				workload.name, fileSize, workload.rw, workload.bs, int(runtime.Seconds()))
+			output, err := utils.PodExec(l.pod, cmd)
+			framework.ExpectNoError(err, "fio workload %s: %s", workload.name, output)
+			result, err := parseFioOutput(output, workload.write)
+			framework.ExpectNoError(err, "fio workload %s", workload.name)
+			result.Workload = workload.name
+			summary.Results = append(summary.Results, result)
+		}
+		framework.Logf("%s", summary.PrintHumanReadable())
+		f.TestSummaries = append(f.TestSummaries, summary)
+
+		var failures []string
+		for _, result := range summary.Results {
+			failures = append(failures, result.check(l.params.Thresholds[result.Workload])...)
+		}
+		if len(failures) > 0 {
+			framework.Failf("IO benchmark below thresholds:\n%s", strings.Join(failures, "\n"))
+		}
+	})
+}
+
+// ioBenchmarkFileSize returns FileSize from the parameters or else the
+// smallest of the default, maxFileSize and half of the claim size, so
+// that the file fits into the volume together with the file system
+// overhead.
+func ioBenchmarkFileSize(params testUtils.IOBenchmarkParameters, maxFileSize int64, claimSize string) (int64, error) {
+	if params.FileSize != nil {
+		return params.FileSize.Value(), nil
+	}
+	fileSize := int64(defaultIOBenchmarkFileSize)
+	if maxFileSize > 0 && maxFileSize < fileSize {
+		fileSize = maxFileSize
+	}
+	if claimSize != "" {
+		claim, err := resource.ParseQuantity(claimSize)
+		if err != nil {
+			return 0, errors.Wrapf(err, "claim size %q", claimSize)
+		}
+		if half := claim.Value() / 2; half < fileSize {
+			fileSize = half
+		}
+	}
+	return fileSize, nil
+}
+
+// ioBenchmarkResult is the performance of one workload.
+type ioBenchmarkResult struct {
+	Workload       string        `json:"workload"`
+	IOPS           float64       `json:"iops"`
+	BandwidthBytes int64         `json:"bandwidthBytes"`
+	MeanLatency    time.Duration `json:"meanLatency"`
+	P99Latency     time.Duration `json:"p99Latency"`
+}
+
+// check returns a description of each threshold that the result
+// does not meet.
+func (r ioBenchmarkResult) check(thresholds testUtils.IOBenchmarkThresholds) []string {
+	var failures []string
+	if thresholds.MinIOPS > 0 && r.IOPS < thresholds.MinIOPS {
+		failures = append(failures, fmt.Sprintf("%s: %.0f IOPS, expected at least %.0f", r.Workload, r.IOPS, thresholds.MinIOPS))
+	}
+	if thresholds.MinBandwidth != nil && r.BandwidthBytes < thresholds.MinBandwidth.Value() {
+		failures = append(failures, fmt.Sprintf("%s: %s/s, expected at least %s/s", r.Workload,
+			resource.NewQuantity(r.BandwidthBytes, resource.BinarySI).String(), thresholds.MinBandwidth.String()))
+	}
+	if thresholds.MaxLatency != nil && r.MeanLatency > thresholds.MaxLatency.Duration {
+		failures = append(failures, fmt.Sprintf("%s: mean latency %v, expected at most %v", r.Workload, r.MeanLatency, thresholds.MaxLatency.Duration))
+	}
+	return failures
+}
+
+// fioOutput is the part of the JSON output of fio that is needed for
+// ioBenchmarkResult.
+type fioOutput struct {
+	Jobs []struct {
+		Read  fioJobStats `json:"read"`
+		Write fioJobStats `json:"write"`
+	} `json:"jobs"`
+}
+
+type fioJobStats struct {
+	IOPS float64 `json:"iops"`
+	// BW is in KiB/s.
+	BW     int64 `json:"bw"`
+	ClatNS struct {
+		Mean       float64            `json:"mean"`
+		Percentile map[string]float64 `json:"percentile"`
+	} `json:"clat_ns"`
+}
+
+// parseFioOutput extracts the result of a single fio job.
+func parseFioOutput(output string, write bool) (ioBenchmarkResult, error) {
+	var result ioBenchmarkResult
+	// kubectl exec may add warnings before the JSON document.
+	if i := strings.Index(output, "{"); i > 0 {
+		output = output[i:]
+	}
+	var parsed fioOutput
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		return result, fmt.Errorf("parsing fio output: %v\n%s", err, output)
+	}
+	if len(parsed.Jobs) != 1 {
+		return result, fmt.Errorf("expected one job in fio output, got %d", len(parsed.Jobs))
+	}
+	stats := parsed.Jobs[0].Read
+	if write {
+		stats = parsed.Jobs[0].Write
+	}
+	result.IOPS = stats.IOPS
+	result.BandwidthBytes = stats.BW * 1024
+	result.MeanLatency = time.Duration(stats.ClatNS.Mean)
+	result.P99Latency = time.Duration(stats.ClatNS.Percentile["99.000000"])
+	return result, nil
+}
+
+// ioBenchmarkSummary is the result of the IO benchmark. It gets added
+// to the test summaries of the framework, so it ends up in
+// --report-dir.
+type ioBenchmarkSummary struct {
+	Driver   string              `json:"driver"`
+	FileSize int64               `json:"fileSize"`
+	Runtime  time.Duration       `json:"runtime"`
+	Results  []ioBenchmarkResult `json:"results"`
+}
+
+var _ framework.TestDataSummary = &ioBenchmarkSummary{}
+
+func (s *ioBenchmarkSummary) SummaryKind() string {
+	return "CSIIOBenchmark"
+}
+
+func (s *ioBenchmarkSummary) PrintHumanReadable() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "IO benchmark of %s with a %s file, %v per workload:\n", s.Driver, resource.NewQuantity(s.FileSize, resource.BinarySI).String(), s.Runtime)
+	fmt.Fprintf(&buf, "%-16s %10s %12s %12s %12s\n", "workload", "IOPS", "bandwidth/s", "mean lat", "99% lat")
+	for _, r := range s.Results {
+		fmt.Fprintf(&buf, "%-16s %10.0f %12s %12v %12v\n", r.Workload, r.IOPS, resource.NewQuantity(r.BandwidthBytes, resource.BinarySI).String(),
+			r.MeanLatency.Round(time.Microsecond), r.P99Latency.Round(time.Microsecond))
+	}
+	return buf.String()
+}
+
+func (s *ioBenchmarkSummary) PrintJSON() string {
+	return framework.PrettyPrintJSON(s)
+}
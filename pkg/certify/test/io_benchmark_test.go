@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/test/e2e/framework"
+)
+
+func TestParseFioOutput(t *testing.T) {
+	// JSON output of "fio --rw=randread --bs=4k --output-format=json",
+	// in the layout of fio 3.x.
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fio-random-read-4k.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(data)
+	read := ioBenchmarkResult{
+		IOPS:           2559.914669,
+		BandwidthBytes: 10239 * 1024,
+		MeanLatency:    383114 * time.Nanosecond,
+		P99Latency:     872448 * time.Nanosecond,
+	}
+
+	for _, test := range []struct {
+		name     string
+		output   string
+		write    bool
+		expected ioBenchmarkResult
+		err      string
+	}{
+		{name: "read", output: output, expected: read},
+		{name: "write", output: output, write: true},
+		{name: "kubectl warning", output: "Defaulting container name to fio.\n" + output, expected: read},
+		{name: "no JSON", output: "fio: pid=0, err=2/file:filesetup.c:703", err: "parsing fio output"},
+		{name: "no jobs", output: `{"jobs": []}`, err: "expected one job in fio output, got 0"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := parseFioOutput(test.output, test.write)
+			switch {
+			case test.err != "" && err == nil:
+				t.Fatalf("expected error %q, got none", test.err)
+			case test.err != "" && !strings.Contains(err.Error(), test.err):
+				t.Fatalf("expected error %q, got: %v", test.err, err)
+			case test.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestIOBenchmarkFileSize(t *testing.T) {
+	twoGi := resource.MustParse("2Gi")
+	for _, test := range []struct {
+		name        string
+		fileSize    *resource.Quantity
+		maxFileSize int64
+		claimSize   string
+		expected    int64
+		err         string
+	}{
+		{name: "default", expected: framework.GiB},
+		{name: "large claim", claimSize: "5Gi", expected: framework.GiB},
+		// The gRPC example driver has 1Gi claims.
+		{name: "small claim", claimSize: "1Gi", expected: 512 * framework.MiB},
+		{name: "max file size", maxFileSize: 100 * framework.MiB, claimSize: "1Gi", expected: 100 * framework.MiB},
+		{name: "explicit", fileSize: &twoGi, claimSize: "1Gi", expected: 2 * framework.GiB},
+		{name: "invalid claim", claimSize: "1 GB", err: "claim size"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fileSize, err := ioBenchmarkFileSize(testUtils.IOBenchmarkParameters{FileSize: test.fileSize}, test.maxFileSize, test.claimSize)
+			switch {
+			case test.err != "" && err == nil:
+				t.Fatalf("expected error %q, got none", test.err)
+			case test.err != "" && !strings.Contains(err.Error(), test.err):
+				t.Fatalf("expected error %q, got: %v", test.err, err)
+			case test.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fileSize != test.expected {
+				t.Errorf("expected %d, got %d", test.expected, fileSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+type mountOptionsTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &mountOptionsTestSuite{}
+
+// InitMountOptionsTestSuite returns mountOptionsTestSuite that implements TestSuite interface
+func InitMountOptionsTestSuite() TestSuite {
+	return &mountOptionsTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name: "mount options",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsPreprovisionedPV,
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *mountOptionsTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *mountOptionsTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		resource *volumeResource
+		pod      *v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		if dInfo.SupportedMountOption.Len() == 0 && dInfo.RequiredMountOption.Len() == 0 {
+			framework.Skipf("Driver %q does not define mount options - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("mountoptions")
+
+	init := func() {
+		l = local{}
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+	}
+
+	// cleanupVolume deletes the volume created by checkMountOptions.
+	cleanupVolume := func() {
+		if l.pod != nil {
+			By("Deleting pod")
+			framework.ExpectNoError(framework.DeletePodWithWait(f, f.ClientSet, l.pod), "delete pod %s", l.pod.Name)
+			l.pod = nil
+		}
+
+		if l.resource != nil {
+			l.resource.cleanupResource()
+			l.resource = nil
+		}
+	}
+
+	cleanup := func() {
+		cleanupVolume()
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+	}
+
+	// checkMountOptions creates a volume with the given mount
+	// options plus the required ones, like the provisioning suite
+	// does, and checks that it gets mounted with all of them.
+	checkMountOptions := func(options []string) {
+		defer cleanupVolume()
+
+		mountOptions := dInfo.RequiredMountOption.Union(sets.NewString(options...))
+		l.resource = createVolumeResourceWithMountOptions(driver, l.config, pattern, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, false, mountOptions.List())
+		l.pod = createPodOnNode(f, l.resource.pvc, l.config.ClientNodeName, false)
+
+		By(fmt.Sprintf("Checking for mount options %v", mountOptions.List()))
+		actual := getMountOptions(l.pod, "/mnt/volume1")
+		if missing := mountOptions.Difference(actual); missing.Len() > 0 {
+			framework.Failf("Volume mounted with options %v, missing %v", actual.List(), missing.List())
+		}
+	}
+
+	It("should mount with each supported mount option", func() {
+		if dInfo.SupportedMountOption.Len() == 0 {
+			framework.Skipf("Driver %q does not define supported mount options - skipping", dInfo.Name)
+		}
+
+		init()
+		defer cleanup()
+
+		for _, option := range dInfo.SupportedMountOption.List() {
+			By("Provisioning with mount option " + option)
+			checkMountOptions([]string{option})
+		}
+	})
+
+	It("should mount with only the required mount options", func() {
+		if dInfo.RequiredMountOption.Len() == 0 {
+			framework.Skipf("Driver %q does not define required mount options - skipping", dInfo.Name)
+		}
+
+		init()
+		defer cleanup()
+
+		checkMountOptions(nil)
+	})
+}
+
+// getMountOptions returns the options with which the directory is
+// mounted inside the pod, as listed in /proc/mounts.
+func getMountOptions(pod *v1.Pod, dir string) sets.String {
+	output, err := utils.PodExec(pod, "cat /proc/mounts")
+	framework.ExpectNoError(err, "read /proc/mounts in pod %s: %s", pod.Name, output)
+	var options sets.String
+	for _, line := range strings.Split(output, "\n") {
+		// device mount point type options dump pass
+		fields := strings.Fields(line)
+		if len(fields) >= 4 && fields[1] == dir {
+			// The last mount for a directory is the visible one.
+			options = sets.NewString(strings.Split(fields[3], ",")...)
+		}
+	}
+	if options == nil {
+		framework.Failf("%s not found in /proc/mounts of pod %s:\n%s", dir, pod.Name, output)
+	}
+	return options
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+// nodePluginRestartTimeout is how long IO may be disrupted after the
+// node plugin got restarted.
+const nodePluginRestartTimeout = 3 * time.Minute
+
+type nodePluginRestartTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &nodePluginRestartTestSuite{}
+
+// InitNodePluginRestartTestSuite returns nodePluginRestartTestSuite that implements TestSuite interface
+func InitNodePluginRestartTestSuite() TestSuite {
+	return &nodePluginRestartTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name:       "node plugin restart",
+			FeatureTag: " [Serial] [Disruptive]",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsPreprovisionedPV,
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *nodePluginRestartTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *nodePluginRestartTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		nodePlugin testUtils.PodSelector
+		resource   *volumeResource
+		pod        *v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		if _, ok := driver.(testUtils.NodePluginTestDriver); !ok {
+			framework.Skipf("Driver %q does not identify its node plugin - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("nodepluginrestart")
+
+	init := func() {
+		l = local{}
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+		l.nodePlugin = getPodSelector(driver.(testUtils.NodePluginTestDriver).GetNodePlugin(l.config), f)
+		if l.nodePlugin.LabelSelector == "" {
+			framework.Skipf("Driver %q does not identify its node plugin - skipping", dInfo.Name)
+		}
+		l.resource = createVolumeResource(driver, l.config, pattern, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, false)
+	}
+
+	cleanup := func() {
+		// Deleting the pod only succeeds once the volume is
+		// unmounted, and deleting the PVC waits for the PV to be
+		// deleted, so both must still work after the restart.
+		if l.pod != nil {
+			By("Deleting pod")
+			framework.ExpectNoError(framework.DeletePodWithWait(f, f.ClientSet, l.pod), "delete pod %s", l.pod.Name)
+			l.pod = nil
+		}
+
+		if l.resource != nil {
+			l.resource.cleanupResource()
+			l.resource = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+	}
+
+	It("should keep a mounted volume usable while the node plugin restarts", func() {
+		init()
+		defer cleanup()
+
+		l.pod = createPodOnNode(f, l.resource.pvc, l.config.ClientNodeName, false)
+		utils.VerifyExecInPodSucceed(l.pod, "echo before >/mnt/volume1/before && sync")
+
+		// The pod got scheduled, so the node is known now.
+		pod, err := f.ClientSet.CoreV1().Pods(l.pod.Namespace).Get(l.pod.Name, metav1.GetOptions{})
+		framework.ExpectNoError(err, "get pod %s", l.pod.Name)
+		l.pod = pod
+		By("Restarting the node plugin on node " + l.pod.Spec.NodeName)
+		restartPods(f.ClientSet, l.nodePlugin, l.pod.Spec.NodeName)
+
+		By("Checking that IO continues or recovers")
+		var output string
+		err = wait.PollImmediate(framework.Poll, nodePluginRestartTimeout, func() (bool, error) {
+			var execErr error
+			output, execErr = utils.PodExec(l.pod, "grep -x before /mnt/volume1/before && echo after >/mnt/volume1/after && sync && grep -x after /mnt/volume1/after")
+			return execErr == nil, nil
+		})
+		framework.ExpectNoError(err, "IO on volume after node plugin restart, last output: %s", output)
+	})
+}
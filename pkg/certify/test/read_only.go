@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+const (
+	readOnlyData = "written before the volume became read-only"
+
+	// checkReadOnlyCommand fails unless writing into the volume is
+	// rejected with EROFS and the data written earlier is readable.
+	checkReadOnlyCommand = `if echo fail >/mnt/volume1/new 2>/tmp/error; then echo "write succeeded"; exit 1; fi; ` +
+		`grep -q "Read-only file system" /tmp/error || { cat /tmp/error; exit 1; }; ` +
+		`grep -x "` + readOnlyData + `" /mnt/volume1/data`
+)
+
+type readOnlyTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &readOnlyTestSuite{}
+
+// InitReadOnlyTestSuite returns readOnlyTestSuite that implements TestSuite interface
+func InitReadOnlyTestSuite() TestSuite {
+	return &readOnlyTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name: "read-only",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsPreprovisionedPV,
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *readOnlyTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *readOnlyTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		resource *volumeResource
+		clonePV  *v1.PersistentVolume
+		clonePVC *v1.PersistentVolumeClaim
+		pods     []*v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		// The data gets written by one pod and read by another.
+		if !dInfo.Capabilities[testsuites.CapPersistence] {
+			framework.Skipf("Driver %q does not provide persistency - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("readonly")
+
+	// init provisions a volume and writes readOnlyData into it.
+	init := func() {
+		l = local{}
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+		l.resource = createVolumeResource(driver, l.config, pattern, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, false)
+
+		By("Writing data")
+		pod := createPodOnNode(f, l.resource.pvc, l.config.ClientNodeName, false)
+		defer func() {
+			framework.ExpectNoError(framework.DeletePodWithWait(f, f.ClientSet, pod), "delete pod %s", pod.Name)
+		}()
+		utils.VerifyExecInPodSucceed(pod, fmt.Sprintf("echo %q >/mnt/volume1/data && sync", readOnlyData))
+	}
+
+	cleanup := func() {
+		var errs []error
+		for _, pod := range l.pods {
+			By("Deleting pod " + pod.Name)
+			errs = append(errs, framework.DeletePodWithWait(f, f.ClientSet, pod))
+		}
+		l.pods = nil
+
+		// The clone uses the Retain policy, so deleting it leaves
+		// the volume alone.
+		if l.clonePV != nil || l.clonePVC != nil {
+			By("Deleting read-only pv and pvc")
+			errs = append(errs, framework.PVPVCCleanup(f.ClientSet, f.Namespace.Name, l.clonePV, l.clonePVC)...)
+			l.clonePV = nil
+			l.clonePVC = nil
+		}
+
+		if l.resource != nil {
+			l.resource.cleanupResource()
+			l.resource = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+		framework.ExpectNoError(utilerrors.NewAggregate(errs), "while cleaning up after test")
+	}
+
+	// createReadOnlyClone creates a second PV for the same volume
+	// which has readOnly set and binds a new PVC to it.
+	createReadOnlyClone := func(accessMode v1.PersistentVolumeAccessMode) {
+		pv := l.resource.pv
+		pvSource := pv.Spec.PersistentVolumeSource.DeepCopy()
+		if pvSource.CSI == nil {
+			framework.Failf("PV %s is not a CSI volume", pv.Name)
+		}
+		pvSource.CSI.ReadOnly = true
+
+		By("Creating read-only pv and pvc")
+		className := f.Namespace.Name + "-readonly"
+		pvConfig := framework.PersistentVolumeConfig{
+			NamePrefix:       "readonly-",
+			StorageClassName: className,
+			PVSource:         *pvSource,
+			NodeAffinity:     pv.Spec.NodeAffinity,
+			ReclaimPolicy:    v1.PersistentVolumeReclaimRetain,
+		}
+		pvcConfig := framework.PersistentVolumeClaimConfig{
+			AccessModes:      []v1.PersistentVolumeAccessMode{accessMode},
+			StorageClassName: &className,
+		}
+		var err error
+		l.clonePV, l.clonePVC, err = framework.CreatePVCPV(f.ClientSet, pvConfig, pvcConfig, f.Namespace.Name, false)
+		Expect(err).NotTo(HaveOccurred(), "PVC, PV creation failed")
+		err = framework.WaitOnPVandPVC(f.ClientSet, f.Namespace.Name, l.clonePV, l.clonePVC)
+		Expect(err).NotTo(HaveOccurred(), "PVC, PV failed to bind")
+	}
+
+	It("should reject writes into a PV with readOnly set", func() {
+		init()
+		defer cleanup()
+
+		createReadOnlyClone(v1.ReadWriteOnce)
+		pod := createPodOnNode(f, l.clonePVC, l.config.ClientNodeName, false)
+		l.pods = append(l.pods, pod)
+		utils.VerifyExecInPodSucceed(pod, checkReadOnlyCommand)
+	})
+
+	It("should reject writes into a read-only volume mount", func() {
+		init()
+		defer cleanup()
+
+		pod := createPodOnNode(f, l.resource.pvc, l.config.ClientNodeName, true)
+		l.pods = append(l.pods, pod)
+		utils.VerifyExecInPodSucceed(pod, checkReadOnlyCommand)
+	})
+
+	It("should allow reading but not writing from pods on different nodes with ReadOnlyMany", func() {
+		if !dInfo.Capabilities[testUtils.CapReadOnlyMany] {
+			framework.Skipf("Driver %q does not support ReadOnlyMany - skipping", dInfo.Name)
+		}
+
+		init()
+		defer cleanup()
+
+		if l.config.ClientNodeName != "" {
+			framework.Skipf("Driver %q restricts pods to node %s - skipping", dInfo.Name, l.config.ClientNodeName)
+		}
+		if l.resource.pv.Spec.NodeAffinity != nil {
+			framework.Skipf("Volume %s is only accessible from some nodes - skipping", l.resource.pv.Name)
+		}
+		node1, node2 := getTwoNodes(f)
+
+		createReadOnlyClone(v1.ReadOnlyMany)
+		for _, node := range []string{node1, node2} {
+			By("Checking read-only access on node " + node)
+			pod := createPodOnNode(f, l.clonePVC, node, false)
+			l.pods = append(l.pods, pod)
+			utils.VerifyExecInPodSucceed(pod, checkReadOnlyCommand)
+		}
+	})
+}
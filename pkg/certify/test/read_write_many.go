@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+type readWriteManyTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &readWriteManyTestSuite{}
+
+// InitReadWriteManyTestSuite returns readWriteManyTestSuite that implements TestSuite interface
+func InitReadWriteManyTestSuite() TestSuite {
+	return &readWriteManyTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name: "ReadWriteMany",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsPreprovisionedPV,
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *readWriteManyTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *readWriteManyTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		resource *volumeResource
+		pods     []*v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		if !dInfo.Capabilities[testUtils.CapReadWriteMany] {
+			framework.Skipf("Driver %q does not support ReadWriteMany - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("readwritemany")
+
+	init := func() {
+		l = local{}
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+		if l.config.ClientNodeName != "" {
+			framework.Skipf("Driver %q restricts pods to node %s - skipping", dInfo.Name, l.config.ClientNodeName)
+		}
+		l.resource = createVolumeResource(driver, l.config, pattern, []v1.PersistentVolumeAccessMode{v1.ReadWriteMany}, false)
+		if l.resource.pv.Spec.NodeAffinity != nil {
+			framework.Skipf("Volume %s is only accessible from some nodes - skipping", l.resource.pv.Name)
+		}
+	}
+
+	cleanup := func() {
+		for _, pod := range l.pods {
+			By("Deleting pod " + pod.Name)
+			framework.ExpectNoError(framework.DeletePodWithWait(f, f.ClientSet, pod), "delete pod %s", pod.Name)
+		}
+		l.pods = nil
+
+		if l.resource != nil {
+			l.resource.cleanupResource()
+			l.resource = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+	}
+
+	It("should write and read data from pods on different nodes", func() {
+		init()
+		defer cleanup()
+
+		nodes := make([]string, 2)
+		nodes[0], nodes[1] = getTwoNodes(f)
+		for _, node := range nodes {
+			By("Writing from node " + node)
+			pod := createPodOnNode(f, l.resource.pvc, node, false)
+			l.pods = append(l.pods, pod)
+			utils.VerifyExecInPodSucceed(pod, fmt.Sprintf("echo %s > /mnt/volume1/%s", node, node))
+		}
+
+		// Both pods still have the volume mounted, so each one
+		// must see what the other one wrote.
+		for i, pod := range l.pods {
+			other := nodes[(i+1)%2]
+			By(fmt.Sprintf("Reading on node %s what was written on node %s", nodes[i], other))
+			utils.VerifyExecInPodSucceed(pod, fmt.Sprintf("grep -x %s /mnt/volume1/%s", other, other))
+		}
+	})
+}
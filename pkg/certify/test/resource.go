@@ -0,0 +1,231 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+// largerVolumeExtraSize is how much larger than the source a volume
+// gets that is created from a data source with a larger size.
+const largerVolumeExtraSize = "1Gi"
+
+// volumeResource is a PVC that is bound to a pre-provisioned or
+// dynamically provisioned PV with the requested access modes. It is
+// the counterpart of the upstream genericVolumeTestResource for
+// suites which need control over access modes and read-only PVs.
+type volumeResource struct {
+	config  *testsuites.PerTestConfig
+	pattern testpatterns.TestPattern
+	pvc     *v1.PersistentVolumeClaim
+	pv      *v1.PersistentVolume
+	sc      *storagev1.StorageClass
+
+	volume testsuites.TestVolume
+}
+
+// createVolumeResource creates the volume for a pattern with
+// PreprovisionedPV or DynamicPV. readOnly is passed to
+// GetPersistentVolumeSource and therefore only has an effect for
+// pre-provisioned PVs.
+func createVolumeResource(driver testsuites.TestDriver, config *testsuites.PerTestConfig, pattern testpatterns.TestPattern, accessModes []v1.PersistentVolumeAccessMode, readOnly bool) *volumeResource {
+	return createVolumeResourceWithMountOptions(driver, config, pattern, accessModes, readOnly, nil)
+}
+
+// createVolumeResourceWithMountOptions is createVolumeResource with
+// additional mount options for the pre-provisioned PV or the storage
+// class.
+func createVolumeResourceWithMountOptions(driver testsuites.TestDriver, config *testsuites.PerTestConfig, pattern testpatterns.TestPattern, accessModes []v1.PersistentVolumeAccessMode, readOnly bool, mountOptions []string) *volumeResource {
+	r := volumeResource{
+		config:  config,
+		pattern: pattern,
+	}
+	dInfo := driver.GetDriverInfo()
+	f := config.Framework
+	cs := f.ClientSet
+	ns := f.Namespace.Name
+	var err error
+
+	// Create volume for pre-provisioned volume tests
+	r.volume = testsuites.CreateVolume(driver, config, pattern.VolType)
+
+	switch pattern.VolType {
+	case testpatterns.PreprovisionedPV:
+		framework.Logf("Creating resource for pre-provisioned PV")
+		if pDriver, ok := driver.(testsuites.PreprovisionedPVTestDriver); ok {
+			pvSource, volumeNodeAffinity := pDriver.GetPersistentVolumeSource(readOnly, pattern.FsType, r.volume)
+			if pvSource == nil {
+				framework.Skipf("Driver %q does not define PersistentVolumeSource - skipping", dInfo.Name)
+			}
+			pvConfig := framework.PersistentVolumeConfig{
+				NamePrefix:       fmt.Sprintf("%s-", dInfo.Name),
+				StorageClassName: ns,
+				PVSource:         *pvSource,
+				NodeAffinity:     volumeNodeAffinity,
+			}
+			pvcConfig := framework.PersistentVolumeClaimConfig{
+				AccessModes:      accessModes,
+				StorageClassName: &ns,
+			}
+			// Like framework.CreatePVCPV, which cannot set mount
+			// options.
+			pv := framework.MakePersistentVolume(pvConfig)
+			pv.Spec.MountOptions = mountOptions
+			r.pvc, err = framework.CreatePVC(cs, ns, framework.MakePersistentVolumeClaim(pvcConfig, ns))
+			Expect(err).NotTo(HaveOccurred(), "PVC creation failed")
+			r.pv, err = framework.CreatePV(cs, pv)
+			Expect(err).NotTo(HaveOccurred(), "PV creation failed")
+			err = framework.WaitOnPVandPVC(cs, ns, r.pv, r.pvc)
+			Expect(err).NotTo(HaveOccurred(), "PVC, PV failed to bind")
+		}
+	case testpatterns.DynamicPV:
+		framework.Logf("Creating resource for dynamic PV")
+		if dDriver, ok := driver.(testsuites.DynamicPVTestDriver); ok {
+			r.sc = dDriver.GetDynamicProvisionStorageClass(config, pattern.FsType)
+			if r.sc == nil {
+				framework.Skipf("Driver %q does not define Dynamic Provision StorageClass - skipping", dInfo.Name)
+			}
+			r.sc.MountOptions = append(r.sc.MountOptions, mountOptions...)
+
+			By("creating a StorageClass " + r.sc.Name)
+			r.sc, err = cs.StorageV1().StorageClasses().Create(r.sc)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("creating a claim")
+			r.pvc = getClaim(dDriver.GetClaimSize(), ns)
+			r.pvc.Spec.StorageClassName = &r.sc.Name
+			r.pvc.Spec.AccessModes = accessModes
+			r.pvc, err = cs.CoreV1().PersistentVolumeClaims(ns).Create(r.pvc)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, cs, r.pvc.Namespace, r.pvc.Name, framework.Poll, framework.ClaimProvisionTimeout)
+			Expect(err).NotTo(HaveOccurred())
+			r.pvc, err = cs.CoreV1().PersistentVolumeClaims(ns).Get(r.pvc.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			r.pv, err = cs.CoreV1().PersistentVolumes().Get(r.pvc.Spec.VolumeName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	default:
+		framework.Failf("volumeResource doesn't support: %s", pattern.VolType)
+	}
+
+	if r.pvc == nil {
+		framework.Skipf("Driver %s doesn't support %v -- skipping", dInfo.Name, pattern.VolType)
+	}
+	return &r
+}
+
+// cleanupResource deletes the PVC and PV and waits for dynamically
+// provisioned PVs to disappear.
+func (r *volumeResource) cleanupResource() {
+	f := r.config.Framework
+	var errs []error
+
+	if r.pvc != nil || r.pv != nil {
+		switch r.pattern.VolType {
+		case testpatterns.PreprovisionedPV:
+			By("Deleting pv and pvc")
+			errs = append(errs, framework.PVPVCCleanup(f.ClientSet, f.Namespace.Name, r.pv, r.pvc)...)
+		case testpatterns.DynamicPV:
+			By("Deleting pvc")
+			if err := framework.DeletePersistentVolumeClaim(f.ClientSet, r.pvc.Name, f.Namespace.Name); err != nil {
+				errs = append(errs, err)
+			} else if r.pv.Spec.PersistentVolumeReclaimPolicy == v1.PersistentVolumeReclaimDelete {
+				if err := framework.WaitForPersistentVolumeDeleted(f.ClientSet, r.pv.Name, 5*time.Second, 5*time.Minute); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	if r.sc != nil {
+		By("Deleting sc")
+		deleteStorageClass(f.ClientSet, r.sc.Name)
+	}
+
+	// Cleanup volume for pre-provisioned volume tests
+	if r.volume != nil {
+		r.volume.DeleteVolume()
+	}
+
+	if len(errs) > 0 {
+		framework.Failf("Failed to delete PVC or PV: %v", utilerrors.NewAggregate(errs))
+	}
+}
+
+// createPodOnNode starts a pod with the PVC mounted at /mnt/volume1
+// on the given node and waits until it runs.
+func createPodOnNode(f *framework.Framework, pvc *v1.PersistentVolumeClaim, nodeName string, readOnly bool) *v1.Pod {
+	pod := framework.MakeSecPod(f.Namespace.Name, []*v1.PersistentVolumeClaim{pvc}, false, "", false, false, framework.SELinuxLabel, nil)
+	pod.Spec.NodeName = nodeName
+	pod.Spec.Containers[0].VolumeMounts[0].ReadOnly = readOnly
+	return runPod(f, pod)
+}
+
+// runPod creates the pod and waits until it runs.
+func runPod(f *framework.Framework, pod *v1.Pod) *v1.Pod {
+	nodeName := pod.Spec.NodeName
+	pod, err := f.ClientSet.CoreV1().Pods(pod.Namespace).Create(pod)
+	framework.ExpectNoError(err, "create pod on node %s", nodeName)
+	err = framework.WaitForPodNameRunningInNamespace(f.ClientSet, pod.Name, pod.Namespace)
+	if err != nil {
+		framework.DeletePodWithWait(f, f.ClientSet, pod)
+		framework.ExpectNoError(err, "pod %s on node %s not running", pod.Name, nodeName)
+	}
+	return pod
+}
+
+// getTwoNodes returns the names of two different schedulable nodes or
+// skips the test when there are fewer.
+func getTwoNodes(f *framework.Framework) (string, string) {
+	nodes := framework.GetReadySchedulableNodesOrDie(f.ClientSet)
+	if len(nodes.Items) < 2 {
+		framework.Skipf("Test needs at least two schedulable nodes, found %d - skipping", len(nodes.Items))
+	}
+	return nodes.Items[0].Name, nodes.Items[1].Name
+}
+
+// createClaimFromDataSource creates a PVC of the given size and storage
+// class which gets populated from the data source and waits until it
+// is bound.
+func createClaimFromDataSource(cs clientset.Interface, ns string, className *string, size resource.Quantity, dataSource *v1.TypedLocalObjectReference) *v1.PersistentVolumeClaim {
+	By(fmt.Sprintf("Creating a pvc of size %s from %s %s", size.String(), dataSource.Kind, dataSource.Name))
+	pvc := getClaim(size.String(), ns)
+	pvc.Spec.StorageClassName = className
+	pvc.Spec.DataSource = dataSource
+	pvc, err := cs.CoreV1().PersistentVolumeClaims(ns).Create(pvc)
+	framework.ExpectNoError(err, "create pvc from %s %s", dataSource.Kind, dataSource.Name)
+	err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, cs, pvc.Namespace, pvc.Name, framework.Poll, framework.ClaimProvisionTimeout)
+	if err != nil {
+		if deleteErr := deleteClaim(cs, pvc); deleteErr != nil {
+			framework.Logf("Failed to delete PVC %s: %v", pvc.Name, deleteErr)
+		}
+		framework.ExpectNoError(err, "pvc %s from %s %s not bound", pvc.Name, dataSource.Kind, dataSource.Name)
+	}
+	pvc, err = cs.CoreV1().PersistentVolumeClaims(ns).Get(pvc.Name, metav1.GetOptions{})
+	framework.ExpectNoError(err, "get pvc %s", pvc.Name)
+	return pvc
+}
+
+// deleteClaim deletes a dynamically provisioned PVC and waits for its
+// PV to be deleted.
+func deleteClaim(cs clientset.Interface, pvc *v1.PersistentVolumeClaim) error {
+	if err := framework.DeletePersistentVolumeClaim(cs, pvc.Name, pvc.Namespace); err != nil {
+		return err
+	}
+	if pvc.Spec.VolumeName == "" {
+		return nil
+	}
+	return framework.WaitForPersistentVolumeDeleted(cs, pvc.Spec.VolumeName, framework.Poll, framework.PVDeletingTimeout)
+}
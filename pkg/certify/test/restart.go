@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/kubernetes/test/e2e/framework"
+)
+
+// podRestartTimeout is how long a DaemonSet, StatefulSet or Deployment
+// may take to replace deleted pods.
+const podRestartTimeout = 3 * time.Minute
+
+// restartPods deletes the selected pods, only those on the node if
+// nodeName is not empty, and waits until the same number of new pods
+// is ready.
+func restartPods(cs clientset.Interface, selector testUtils.PodSelector, nodeName string) {
+	oldPods := listPods(cs, selector, nodeName)
+	if len(oldPods) == 0 {
+		framework.Failf("No pods with labels %q in namespace %s on node %q", selector.LabelSelector, selector.Namespace, nodeName)
+	}
+
+	oldUIDs := sets.NewString()
+	for _, pod := range oldPods {
+		By(fmt.Sprintf("Deleting pod %s on node %s", pod.Name, pod.Spec.NodeName))
+		err := cs.CoreV1().Pods(pod.Namespace).Delete(pod.Name, metav1.NewDeleteOptions(0))
+		framework.ExpectNoError(err, "delete pod %s", pod.Name)
+		oldUIDs.Insert(string(pod.UID))
+	}
+
+	By("Waiting for the pods to come back")
+	err := wait.PollImmediate(framework.Poll, podRestartTimeout, func() (bool, error) {
+		ready := 0
+		for _, pod := range listPods(cs, selector, nodeName) {
+			if !oldUIDs.Has(string(pod.UID)) && podutil.IsPodReady(&pod) {
+				ready++
+			}
+		}
+		return ready >= len(oldPods), nil
+	})
+	framework.ExpectNoError(err, "new pods with labels %q in namespace %s", selector.LabelSelector, selector.Namespace)
+}
+
+// listPods returns the selected pods which are not being deleted,
+// only those on the node if nodeName is not empty.
+func listPods(cs clientset.Interface, selector testUtils.PodSelector, nodeName string) []v1.Pod {
+	pods, err := cs.CoreV1().Pods(selector.Namespace).List(metav1.ListOptions{LabelSelector: selector.LabelSelector})
+	framework.ExpectNoError(err, "list pods with labels %q in namespace %s", selector.LabelSelector, selector.Namespace)
+	var result []v1.Pod
+	for _, pod := range pods.Items {
+		if (nodeName == "" || pod.Spec.NodeName == nodeName) && pod.DeletionTimestamp == nil {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// getPodSelector returns the selector with the test namespace filled
+// in if it was left empty.
+func getPodSelector(selector testUtils.PodSelector, f *framework.Framework) testUtils.PodSelector {
+	if selector.Namespace == "" {
+		selector.Namespace = f.Namespace.Name
+	}
+	return selector
+}
+
+// runPodCommand executes the command in each of the selected pods and
+// returns the non-empty output lines of all of them.
+func runPodCommand(cs clientset.Interface, command testUtils.PodCommand) []string {
+	pods := listPods(cs, command.Pods, "")
+	if len(pods) == 0 {
+		framework.Failf("No pods with labels %q in namespace %s", command.Pods.LabelSelector, command.Pods.Namespace)
+	}
+	var lines []string
+	for _, pod := range pods {
+		args := []string{"exec", "--namespace=" + pod.Namespace, pod.Name}
+		if command.Container != "" {
+			args = append(args, "--container="+command.Container)
+		}
+		args = append(args, "--", "/bin/sh", "-c", command.Command)
+		output, err := framework.RunKubectl(args...)
+		framework.ExpectNoError(err, "%q in pod %s: %s", command.Command, pod.Name, output)
+		for _, line := range strings.Split(output, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
@@ -0,0 +1,324 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+const defaultScaleConcurrency = 10
+
+type scaleTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &scaleTestSuite{}
+
+// InitScaleTestSuite returns scaleTestSuite that implements TestSuite interface
+func InitScaleTestSuite() TestSuite {
+	return &scaleTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name:       "scale",
+			FeatureTag: " [Serial] [Slow]",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *scaleTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *scaleTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		params testUtils.ScaleParameters
+		sc     *storagev1.StorageClass
+		// pvcs and pods are indexed by the work piece which
+		// created them and nil when not created or already
+		// deleted.
+		pvcs []*v1.PersistentVolumeClaim
+		pods []*v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		sDriver, ok := driver.(testUtils.ScaleTestDriver)
+		if !ok || sDriver.GetScaleParameters().Count <= 0 {
+			framework.Skipf("Driver %q does not define the number of volumes for the scale test - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("scale")
+
+	init := func() {
+		l = local{}
+		l.params = driver.(testUtils.ScaleTestDriver).GetScaleParameters()
+		if l.params.Concurrency <= 0 {
+			l.params.Concurrency = defaultScaleConcurrency
+		}
+		l.pvcs = make([]*v1.PersistentVolumeClaim, l.params.Count)
+		l.pods = make([]*v1.Pod, l.params.Count)
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+
+		dDriver, _ := driver.(testsuites.DynamicPVTestDriver)
+		sc := dDriver.GetDynamicProvisionStorageClass(l.config, pattern.FsType)
+		if sc == nil {
+			framework.Skipf("Driver %q does not define Dynamic Provision StorageClass - skipping", dInfo.Name)
+		}
+		By("Creating sc")
+		var err error
+		l.sc, err = f.ClientSet.StorageV1().StorageClasses().Create(sc)
+		framework.ExpectNoError(err, "create StorageClass")
+	}
+
+	// cleanup removes whatever the test did not delete itself,
+	// typically because it failed.
+	cleanup := func() {
+		for _, pod := range l.pods {
+			if pod != nil {
+				if err := framework.DeletePodWithWait(f, f.ClientSet, pod); err != nil {
+					framework.Logf("Failed to delete pod %s: %v", pod.Name, err)
+				}
+			}
+		}
+		l.pods = nil
+
+		for _, pvc := range l.pvcs {
+			if pvc != nil {
+				if err := deleteClaim(f.ClientSet, pvc); err != nil {
+					framework.Logf("Failed to delete PVC %s: %v", pvc.Name, err)
+				}
+			}
+		}
+		l.pvcs = nil
+
+		if l.sc != nil {
+			By("Deleting sc")
+			deleteStorageClass(f.ClientSet, l.sc.Name)
+			l.sc = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+	}
+
+	// parallelize runs work for all volumes, with at most
+	// l.params.Concurrency of them at once.
+	parallelize := func(work func(i int)) {
+		workqueue.Parallelize(l.params.Concurrency, l.params.Count, work)
+	}
+
+	It("should provision, attach and delete many volumes concurrently", func() {
+		init()
+		defer cleanup()
+
+		cs := f.ClientSet
+		ns := f.Namespace.Name
+		claimSize := driver.(testsuites.DynamicPVTestDriver).GetClaimSize()
+		var provisioning, attach, detach, deletion latencyRecorder
+
+		By(fmt.Sprintf("Provisioning %d volumes, %d at a time", l.params.Count, l.params.Concurrency))
+		parallelize(func(i int) {
+			pvc := getClaim(claimSize, ns)
+			pvc.Spec.StorageClassName = &l.sc.Name
+			start := time.Now()
+			pvc, err := cs.CoreV1().PersistentVolumeClaims(ns).Create(pvc)
+			if err != nil {
+				provisioning.record(fmt.Sprintf("pvc #%d", i), start, err)
+				return
+			}
+			l.pvcs[i] = pvc
+			err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, cs, ns, pvc.Name, framework.Poll, framework.ClaimProvisionTimeout)
+			provisioning.record(pvc.Name, start, err)
+			if err == nil {
+				// Deleting the PVC waits for the PV, whose
+				// name is only known now.
+				if pvc, err = cs.CoreV1().PersistentVolumeClaims(ns).Get(pvc.Name, metav1.GetOptions{}); err == nil {
+					l.pvcs[i] = pvc
+				}
+			}
+		})
+
+		By("Starting a pod for each volume")
+		parallelize(func(i int) {
+			pvc := l.pvcs[i]
+			if pvc == nil || pvc.Spec.VolumeName == "" {
+				return
+			}
+			pod := framework.MakeSecPod(ns, []*v1.PersistentVolumeClaim{pvc}, false, "", false, false, framework.SELinuxLabel, nil)
+			pod.Spec.NodeName = l.config.ClientNodeName
+			start := time.Now()
+			pod, err := cs.CoreV1().Pods(ns).Create(pod)
+			if err != nil {
+				attach.record(fmt.Sprintf("pod for %s", pvc.Name), start, err)
+				return
+			}
+			l.pods[i] = pod
+			attach.record(pod.Name, start, framework.WaitForPodNameRunningInNamespace(cs, pod.Name, ns))
+		})
+
+		By("Deleting the pods")
+		parallelize(func(i int) {
+			pod := l.pods[i]
+			if pod == nil {
+				return
+			}
+			start := time.Now()
+			err := framework.DeletePodWithWait(f, cs, pod)
+			detach.record(pod.Name, start, err)
+			if err == nil {
+				l.pods[i] = nil
+			}
+		})
+
+		By("Deleting the volumes")
+		parallelize(func(i int) {
+			pvc := l.pvcs[i]
+			if pvc == nil {
+				return
+			}
+			start := time.Now()
+			err := deleteClaim(cs, pvc)
+			deletion.record(pvc.Name, start, err)
+			if err == nil {
+				l.pvcs[i] = nil
+			}
+		})
+
+		summary := &scaleSummary{
+			Driver:       dInfo.Name,
+			Count:        l.params.Count,
+			Concurrency:  l.params.Concurrency,
+			Provisioning: provisioning.summary(),
+			Attach:       attach.summary(),
+			Detach:       detach.summary(),
+			Deletion:     deletion.summary(),
+		}
+		framework.Logf("%s", summary.PrintHumanReadable())
+		f.TestSummaries = append(f.TestSummaries, summary)
+
+		var errs []error
+		for _, r := range []*latencyRecorder{&provisioning, &attach, &detach, &deletion} {
+			errs = append(errs, r.errs...)
+		}
+		framework.ExpectNoError(utilerrors.NewAggregate(errs), "%d operations failed", summary.failures())
+	})
+}
+
+// latencyRecorder collects the duration of one kind of operation for
+// many volumes. It is safe for concurrent use.
+type latencyRecorder struct {
+	mutex     sync.Mutex
+	latencies []framework.PodLatencyData
+	errs      []error
+}
+
+// record adds the time since start for a successful operation or the
+// error of a failed one.
+func (r *latencyRecorder) record(name string, start time.Time, err error) {
+	latency := time.Since(start)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s: %v", name, err))
+		return
+	}
+	r.latencies = append(r.latencies, framework.PodLatencyData{Name: name, Latency: latency})
+}
+
+func (r *latencyRecorder) summary() scaleLatency {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	s := scaleLatency{
+		Succeeded: len(r.latencies),
+		Failed:    len(r.errs),
+	}
+	if len(r.latencies) > 0 {
+		sort.Sort(framework.LatencySlice(r.latencies))
+		s.LatencyMetric = framework.ExtractLatencyMetrics(r.latencies)
+	}
+	return s
+}
+
+// scaleLatency are the latency percentiles of the successful
+// operations of one kind.
+type scaleLatency struct {
+	framework.LatencyMetric
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// scaleSummary is the result of the scale test. It gets added to the
+// test summaries of the framework, so it ends up in --report-dir.
+type scaleSummary struct {
+	Driver       string       `json:"driver"`
+	Count        int          `json:"count"`
+	Concurrency  int          `json:"concurrency"`
+	Provisioning scaleLatency `json:"provisioning"`
+	Attach       scaleLatency `json:"attach"`
+	Detach       scaleLatency `json:"detach"`
+	Deletion     scaleLatency `json:"deletion"`
+}
+
+var _ framework.TestDataSummary = &scaleSummary{}
+
+func (s *scaleSummary) SummaryKind() string {
+	return "CSIScaleLatency"
+}
+
+func (s *scaleSummary) PrintHumanReadable() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "Scale test of %s with %d volumes, %d at a time:\n", s.Driver, s.Count, s.Concurrency)
+	fmt.Fprintf(&buf, "%-13s %9s %6s %12s %12s %12s %12s\n", "operation", "succeeded", "failed", "50%", "90%", "99%", "100%")
+	for _, op := range []struct {
+		name    string
+		latency scaleLatency
+	}{
+		{"provisioning", s.Provisioning},
+		{"attach", s.Attach},
+		{"detach", s.Detach},
+		{"deletion", s.Deletion},
+	} {
+		l := op.latency
+		fmt.Fprintf(&buf, "%-13s %9d %6d %12v %12v %12v %12v\n", op.name, l.Succeeded, l.Failed,
+			l.Perc50.Round(time.Millisecond), l.Perc90.Round(time.Millisecond), l.Perc99.Round(time.Millisecond), l.Perc100.Round(time.Millisecond))
+	}
+	return buf.String()
+}
+
+func (s *scaleSummary) PrintJSON() string {
+	return framework.PrettyPrintJSON(s)
+}
+
+func (s *scaleSummary) failures() int {
+	return s.Provisioning.Failed + s.Attach.Failed + s.Detach.Failed + s.Deletion.Failed
+}
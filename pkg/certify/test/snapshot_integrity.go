@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+// The snapshot API as used by the vendored testsuites package, which
+// does not export it.
+const snapshotGroup = "snapshot.storage.k8s.io"
+
+var (
+	snapshotGVR      = schema.GroupVersionResource{Group: snapshotGroup, Version: "v1alpha1", Resource: "volumesnapshots"}
+	snapshotClassGVR = schema.GroupVersionResource{Group: snapshotGroup, Version: "v1alpha1", Resource: "volumesnapshotclasses"}
+)
+
+const (
+	// snapshotDataFile is the file with pseudo-random data whose
+	// checksum gets compared.
+	snapshotDataFile = "/mnt/volume1/data"
+
+	// maxSnapshotDataSize limits the size of snapshotDataFile for
+	// drivers with a large DriverInfo.MaxFileSize.
+	maxSnapshotDataSize = testpatterns.FileSizeMedium
+)
+
+type snapshotIntegrityTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &snapshotIntegrityTestSuite{}
+
+// InitSnapshotIntegrityTestSuite returns snapshotIntegrityTestSuite that implements TestSuite interface
+func InitSnapshotIntegrityTestSuite() TestSuite {
+	return &snapshotIntegrityTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name:       "snapshot data integrity",
+			FeatureTag: " [Feature:VolumeSnapshotDataSource]",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *snapshotIntegrityTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *snapshotIntegrityTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		resource    *volumeResource
+		vsc         *unstructured.Unstructured
+		snapshot    *unstructured.Unstructured
+		restoredPVC *v1.PersistentVolumeClaim
+		pods        []*v1.Pod
+	}
+	var (
+		dInfo   = driver.GetDriverInfo()
+		sDriver testsuites.SnapshottableTestDriver
+		l       local
+	)
+
+	BeforeEach(func() {
+		var ok bool
+		sDriver, ok = driver.(testsuites.SnapshottableTestDriver)
+		if !dInfo.Capabilities[testsuites.CapDataSource] || !ok {
+			framework.Skipf("Driver %q does not support snapshots - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("snapshotintegrity")
+
+	init := func() {
+		l = local{}
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+		vsc := sDriver.GetSnapshotClass(l.config)
+		if vsc == nil {
+			framework.Skipf("Driver %q does not define a VolumeSnapshotClass - skipping", dInfo.Name)
+		}
+		l.resource = createVolumeResource(driver, l.config, pattern, []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, false)
+
+		By("Creating a SnapshotClass")
+		var err error
+		l.vsc, err = f.DynamicClient.Resource(snapshotClassGVR).Create(vsc, metav1.CreateOptions{})
+		framework.ExpectNoError(err, "create VolumeSnapshotClass")
+	}
+
+	cleanup := func() {
+		var errs []error
+		for _, pod := range l.pods {
+			By("Deleting pod " + pod.Name)
+			errs = append(errs, framework.DeletePodWithWait(f, f.ClientSet, pod))
+		}
+		l.pods = nil
+
+		if l.restoredPVC != nil {
+			By("Deleting restored pvc")
+			errs = append(errs, deleteClaim(f.ClientSet, l.restoredPVC))
+			l.restoredPVC = nil
+		}
+
+		if l.snapshot != nil {
+			By("Deleting snapshot")
+			err := f.DynamicClient.Resource(snapshotGVR).Namespace(l.snapshot.GetNamespace()).Delete(l.snapshot.GetName(), nil)
+			if err != nil && !apierrs.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+			l.snapshot = nil
+		}
+
+		if l.vsc != nil {
+			By("Deleting SnapshotClass")
+			errs = append(errs, f.DynamicClient.Resource(snapshotClassGVR).Delete(l.vsc.GetName(), nil))
+			l.vsc = nil
+		}
+
+		if l.resource != nil {
+			l.resource.cleanupResource()
+			l.resource = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+		framework.ExpectNoError(utilerrors.NewAggregate(errs), "while cleaning up after test")
+	}
+
+	// startPod starts a pod which uses the PVC. It gets deleted
+	// during cleanup.
+	startPod := func(pvc *v1.PersistentVolumeClaim) *v1.Pod {
+		pod := createPodOnNode(f, pvc, l.config.ClientNodeName, false)
+		l.pods = append(l.pods, pod)
+		return pod
+	}
+
+	// stopPod deletes a pod started by startPod.
+	stopPod := func(pod *v1.Pod) {
+		framework.ExpectNoError(framework.DeletePodWithWait(f, f.ClientSet, pod), "delete pod %s", pod.Name)
+		for i := range l.pods {
+			if l.pods[i] == pod {
+				l.pods = append(l.pods[:i], l.pods[i+1:]...)
+				break
+			}
+		}
+	}
+
+	// writeData replaces snapshotDataFile with new pseudo-random
+	// data and returns its checksum.
+	writeData := func(pod *v1.Pod) string {
+		size := snapshotDataSize(dInfo.MaxFileSize)
+		By(fmt.Sprintf("Writing %d MiB of pseudo-random data", size/framework.MiB))
+		utils.VerifyExecInPodSucceed(pod, fmt.Sprintf("dd if=/dev/urandom of=%s bs=%d count=%d && sync", snapshotDataFile, framework.MiB, size/framework.MiB))
+		return checksum(pod)
+	}
+
+	createSnapshot := func() {
+		By("Creating a snapshot")
+		pvc := l.resource.pvc
+		snapshot := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind":       "VolumeSnapshot",
+				"apiVersion": snapshotGroup + "/v1alpha1",
+				"metadata": map[string]interface{}{
+					"generateName": "snapshot-",
+					"namespace":    pvc.Namespace,
+				},
+				"spec": map[string]interface{}{
+					"snapshotClassName": l.vsc.GetName(),
+					"source": map[string]interface{}{
+						"name": pvc.Name,
+						"kind": "PersistentVolumeClaim",
+					},
+				},
+			},
+		}
+		var err error
+		l.snapshot, err = f.DynamicClient.Resource(snapshotGVR).Namespace(pvc.Namespace).Create(snapshot, metav1.CreateOptions{})
+		framework.ExpectNoError(err, "create snapshot of pvc %s", pvc.Name)
+		err = testsuites.WaitForSnapshotReady(f.DynamicClient, l.snapshot.GetNamespace(), l.snapshot.GetName(), framework.Poll, framework.SnapshotCreateTimeout)
+		framework.ExpectNoError(err, "snapshot %s not ready", l.snapshot.GetName())
+	}
+
+	// checkRestore restores the snapshot into a new volume of the
+	// given size and compares the checksum of snapshotDataFile.
+	checkRestore := func(size resource.Quantity, expectedChecksum string) {
+		group := snapshotGroup
+		l.restoredPVC = createClaimFromDataSource(f.ClientSet, l.resource.pvc.Namespace, l.resource.pvc.Spec.StorageClassName, size, &v1.TypedLocalObjectReference{
+			APIGroup: &group,
+			Kind:     "VolumeSnapshot",
+			Name:     l.snapshot.GetName(),
+		})
+		pod := startPod(l.restoredPVC)
+		By("Checking the data in the restored volume")
+		Expect(checksum(pod)).To(Equal(expectedChecksum), "checksum of %s in the volume restored from snapshot %s", snapshotDataFile, l.snapshot.GetName())
+	}
+
+	// testRestore snapshots the source volume, either while a pod
+	// uses it or not, keeps writing into the source and then checks
+	// the restored data.
+	testRestore := func(mounted bool, size resource.Quantity) {
+		pod := startPod(l.resource.pvc)
+		snapshotChecksum := writeData(pod)
+		if !mounted {
+			stopPod(pod)
+		}
+		createSnapshot()
+
+		if !mounted {
+			pod = startPod(l.resource.pvc)
+		}
+		By("Writing into the source after taking the snapshot")
+		Expect(writeData(pod)).NotTo(Equal(snapshotChecksum), "checksum of new data")
+
+		checkRestore(size, snapshotChecksum)
+	}
+
+	It("should restore the data of a snapshot of an unmounted volume", func() {
+		init()
+		defer cleanup()
+
+		testRestore(false, l.resource.pvc.Spec.Resources.Requests[v1.ResourceStorage])
+	})
+
+	It("should restore the data of a snapshot of a mounted volume", func() {
+		init()
+		defer cleanup()
+
+		testRestore(true, l.resource.pvc.Spec.Resources.Requests[v1.ResourceStorage])
+	})
+
+	It("should restore the data of a snapshot into a larger volume", func() {
+		init()
+		defer cleanup()
+
+		size := l.resource.pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		size.Add(resource.MustParse(largerVolumeExtraSize))
+		testRestore(false, size)
+	})
+}
+
+// snapshotDataSize returns the size of the data written for the
+// snapshot tests, a multiple of 1 MiB.
+func snapshotDataSize(maxFileSize int64) int64 {
+	size := int64(maxSnapshotDataSize)
+	if maxFileSize > 0 && maxFileSize < size {
+		size = maxFileSize
+	}
+	if size < testpatterns.MinFileSize {
+		size = testpatterns.MinFileSize
+	}
+	return size / framework.MiB * framework.MiB
+}
+
+// checksum returns the sha256 of snapshotDataFile in the pod.
+func checksum(pod *v1.Pod) string {
+	output, err := utils.PodExec(pod, "sha256sum "+snapshotDataFile)
+	framework.ExpectNoError(err, "sha256sum in pod %s: %s", pod.Name, output)
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		framework.Failf("unexpected output of sha256sum in pod %s: %q", pod.Name, output)
+	}
+	return fields[0]
+}
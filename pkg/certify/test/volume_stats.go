@@ -0,0 +1,291 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	testUtils "github.com/wongma7/csi-certify/pkg/certify/utils"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	stats "k8s.io/kubernetes/pkg/kubelet/apis/stats/v1alpha1"
+	"k8s.io/kubernetes/pkg/master/ports"
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+	"k8s.io/kubernetes/test/e2e/storage/utils"
+)
+
+const (
+	// volumeStatsDataMiB is the amount of data written into the volume.
+	volumeStatsDataMiB = 32
+
+	// volumeStatsFiles is the number of files created in the volume,
+	// including the one with the data.
+	volumeStatsFiles = 100
+
+	// volumeStatsTimeout covers kubelet's caching of volume stats,
+	// which get refreshed only once per minute by default.
+	volumeStatsTimeout = 3 * time.Minute
+
+	defaultUsedBytesTolerance       = "16Mi"
+	defaultCapacityPercentTolerance = 10
+)
+
+type volumeStatsTestSuite struct {
+	tsInfo TestSuiteInfo
+}
+
+var _ TestSuite = &volumeStatsTestSuite{}
+
+// InitVolumeStatsTestSuite returns volumeStatsTestSuite that implements TestSuite interface
+func InitVolumeStatsTestSuite() TestSuite {
+	return &volumeStatsTestSuite{
+		tsInfo: TestSuiteInfo{
+			Name: "volume stats",
+			TestPatterns: []testpatterns.TestPattern{
+				testpatterns.DefaultFsDynamicPV,
+			},
+		},
+	}
+}
+
+func (t *volumeStatsTestSuite) GetTestSuiteInfo() TestSuiteInfo {
+	return t.tsInfo
+}
+
+func (t *volumeStatsTestSuite) DefineTests(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	type local struct {
+		config      *testsuites.PerTestConfig
+		testCleanup func()
+
+		cs  clientset.Interface
+		ns  *v1.Namespace
+		sc  *storagev1.StorageClass
+		pvc *v1.PersistentVolumeClaim
+		pod *v1.Pod
+	}
+	var (
+		dInfo = driver.GetDriverInfo()
+		l     local
+	)
+
+	BeforeEach(func() {
+		if !dInfo.Capabilities[testUtils.CapVolumeStats] {
+			framework.Skipf("Driver %q does not report volume stats - skipping", dInfo.Name)
+		}
+	})
+
+	// This intentionally comes after checking the preconditions because it
+	// registers its own BeforeEach which creates the namespace. Beware that it
+	// also registers an AfterEach which renders f unusable. Any code using
+	// f must run inside an It or Context callback.
+	f := framework.NewDefaultFramework("volumestats")
+
+	init := func() {
+		l = local{}
+		l.ns = f.Namespace
+		l.cs = f.ClientSet
+
+		// Now do the more expensive test initialization.
+		l.config, l.testCleanup = driver.PrepareTest(f)
+
+		dDriver, _ := driver.(testsuites.DynamicPVTestDriver)
+		l.sc = dDriver.GetDynamicProvisionStorageClass(l.config, pattern.FsType)
+		if l.sc == nil {
+			framework.Skipf("Driver %q does not define Dynamic Provision StorageClass - skipping", dInfo.Name)
+		}
+		l.pvc = getClaim(dDriver.GetClaimSize(), l.ns.Name)
+		l.pvc.Spec.StorageClassName = &l.sc.Name
+	}
+
+	cleanup := func() {
+		if l.pod != nil {
+			By("Deleting pod")
+			if err := framework.DeletePodWithWait(f, l.cs, l.pod); err != nil {
+				framework.Logf("Failed to delete pod %s: %v", l.pod.Name, err)
+			}
+			l.pod = nil
+		}
+
+		if l.pvc != nil && l.pvc.Name != "" {
+			By("Deleting pvc")
+			if err := framework.DeletePersistentVolumeClaim(l.cs, l.pvc.Name, l.pvc.Namespace); err != nil {
+				framework.Logf("Failed to delete PVC %s: %v", l.pvc.Name, err)
+			}
+		}
+		l.pvc = nil
+
+		if l.sc != nil {
+			By("Deleting sc")
+			deleteStorageClass(l.cs, l.sc.Name)
+			l.sc = nil
+		}
+
+		if l.testCleanup != nil {
+			l.testCleanup()
+			l.testCleanup = nil
+		}
+	}
+
+	It("should report plausible capacity and usage of a mounted volume", func() {
+		init()
+		defer cleanup()
+
+		var err error
+		tolerances := getVolumeStatsTolerances(driver)
+
+		By("Creating sc")
+		l.sc, err = l.cs.StorageV1().StorageClasses().Create(l.sc)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Creating pvc")
+		l.pvc, err = framework.CreatePVC(l.cs, l.ns.Name, l.pvc)
+		Expect(err).NotTo(HaveOccurred())
+		err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, l.cs, l.pvc.Namespace, l.pvc.Name, framework.Poll, framework.ClaimProvisionTimeout)
+		Expect(err).NotTo(HaveOccurred())
+		l.pvc, err = l.cs.CoreV1().PersistentVolumeClaims(l.pvc.Namespace).Get(l.pvc.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		pv, err := l.cs.CoreV1().PersistentVolumes().Get(l.pvc.Spec.VolumeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Creating pod")
+		l.pod, err = framework.CreateSecPodWithNodeName(l.cs, l.ns.Name, []*v1.PersistentVolumeClaim{l.pvc},
+			false, "", false, false, framework.SELinuxLabel,
+			nil, l.config.ClientNodeName, framework.PodStartTimeout)
+		Expect(err).NotTo(HaveOccurred())
+		l.pod, err = l.cs.CoreV1().Pods(l.pod.Namespace).Get(l.pod.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for initial volume stats")
+		before := waitForVolumeStats(l.cs, l.pod.Spec.NodeName, l.pvc, func(*stats.VolumeStats) bool { return true })
+		checkVolumeCapacity(before, pv, tolerances)
+
+		By(fmt.Sprintf("Writing %dMiB into %d files", volumeStatsDataMiB, volumeStatsFiles))
+		utils.VerifyExecInPodSucceed(l.pod, fmt.Sprintf(
+			"dd if=/dev/urandom of=/mnt/volume1/data bs=1048576 count=%d && for i in $(seq 2 %d); do touch /mnt/volume1/file-$i || exit 1; done && sync",
+			volumeStatsDataMiB, volumeStatsFiles))
+		written := uint64(volumeStatsDataMiB * 1024 * 1024)
+		margin := uint64(tolerances.UsedBytes.Value())
+
+		By("Waiting for the volume stats to include the new data")
+		after := waitForVolumeStats(l.cs, l.pod.Spec.NodeName, l.pvc, func(vs *stats.VolumeStats) bool {
+			return *vs.UsedBytes+margin >= *before.UsedBytes+written
+		})
+		checkVolumeCapacity(after, pv, tolerances)
+		Expect(*after.UsedBytes).To(BeNumerically("<=", *before.UsedBytes+written+margin),
+			"used bytes before writing %d bytes: %d", written, *before.UsedBytes)
+		if before.InodesUsed != nil && after.InodesUsed != nil {
+			Expect(*after.InodesUsed).To(BeNumerically(">=", *before.InodesUsed+volumeStatsFiles),
+				"used inodes before creating %d files: %d", volumeStatsFiles, *before.InodesUsed)
+		}
+	})
+}
+
+// getVolumeStatsTolerances returns the tolerances of the driver with
+// defaults for those that it doesn't set.
+func getVolumeStatsTolerances(driver testsuites.TestDriver) testUtils.VolumeStatsTolerances {
+	var tolerances testUtils.VolumeStatsTolerances
+	if vDriver, ok := driver.(testUtils.VolumeStatsTestDriver); ok {
+		tolerances = vDriver.GetVolumeStatsTolerances()
+	}
+	if tolerances.UsedBytes == nil {
+		usedBytes := resource.MustParse(defaultUsedBytesTolerance)
+		tolerances.UsedBytes = &usedBytes
+	}
+	if tolerances.CapacityPercent == nil {
+		capacityPercent := defaultCapacityPercentTolerance
+		tolerances.CapacityPercent = &capacityPercent
+	}
+	return tolerances
+}
+
+// checkVolumeCapacity checks that the reported numbers are consistent
+// with each other and with the size of the volume.
+func checkVolumeCapacity(vs *stats.VolumeStats, pv *v1.PersistentVolume, tolerances testUtils.VolumeStatsTolerances) {
+	Expect(vs.CapacityBytes).NotTo(BeNil(), "capacity bytes")
+	Expect(vs.AvailableBytes).NotTo(BeNil(), "available bytes")
+	Expect(*vs.AvailableBytes).To(BeNumerically("<=", *vs.CapacityBytes), "available bytes")
+	Expect(*vs.UsedBytes).To(BeNumerically("<=", *vs.CapacityBytes), "used bytes")
+	if vs.Inodes != nil && vs.InodesFree != nil {
+		Expect(*vs.InodesFree).To(BeNumerically("<=", *vs.Inodes), "free inodes")
+	}
+
+	size := pv.Spec.Capacity[v1.ResourceStorage]
+	maxCapacity := uint64(size.Value())
+	minCapacity := maxCapacity / 100 * uint64(100-*tolerances.CapacityPercent)
+	Expect(*vs.CapacityBytes).To(BeNumerically("<=", maxCapacity), "capacity bytes of PV %s with size %s", pv.Name, size.String())
+	Expect(*vs.CapacityBytes).To(BeNumerically(">=", minCapacity), "capacity bytes of PV %s with size %s", pv.Name, size.String())
+}
+
+// waitForVolumeStats polls the kubelet summary API of the node until
+// it reports stats for the PVC which satisfy the condition.
+func waitForVolumeStats(cs clientset.Interface, nodeName string, pvc *v1.PersistentVolumeClaim, condition func(*stats.VolumeStats) bool) *stats.VolumeStats {
+	var volumeStats *stats.VolumeStats
+	err := wait.PollImmediate(framework.Poll, volumeStatsTimeout, func() (bool, error) {
+		summary, err := getStatsSummary(cs, nodeName)
+		if err != nil {
+			framework.Logf("Failed to get stats summary of node %s: %v", nodeName, err)
+			return false, nil
+		}
+		vs := findVolumeStats(summary, pvc)
+		if vs == nil || vs.UsedBytes == nil || vs.CapacityBytes == nil {
+			return false, nil
+		}
+		volumeStats = vs
+		return condition(vs), nil
+	})
+	if volumeStats == nil {
+		framework.Failf("Node %s does not report stats for PVC %s: %v", nodeName, pvc.Name, err)
+	}
+	framework.ExpectNoError(err, "stats for PVC %s, last reported: %s", pvc.Name, formatVolumeStats(volumeStats))
+	framework.Logf("Stats for PVC %s: %s", pvc.Name, formatVolumeStats(volumeStats))
+	return volumeStats
+}
+
+func findVolumeStats(summary *stats.Summary, pvc *v1.PersistentVolumeClaim) *stats.VolumeStats {
+	for _, pod := range summary.Pods {
+		for i := range pod.VolumeStats {
+			vs := &pod.VolumeStats[i]
+			if vs.PVCRef != nil && vs.PVCRef.Namespace == pvc.Namespace && vs.PVCRef.Name == pvc.Name {
+				return vs
+			}
+		}
+	}
+	return nil
+}
+
+func formatVolumeStats(vs *stats.VolumeStats) string {
+	data, err := json.Marshal(vs.FsStats)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// getStatsSummary queries the kubelet summary API through the API
+// server's node proxy.
+func getStatsSummary(cs clientset.Interface, nodeName string) (*stats.Summary, error) {
+	data, err := cs.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		SubResource("proxy").
+		Name(fmt.Sprintf("%v:%v", nodeName, ports.KubeletPort)).
+		Suffix("stats/summary").
+		Timeout(framework.SingleCallTimeout).
+		Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := stats.Summary{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
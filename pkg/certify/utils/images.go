@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+)
+
+// csiImagePrefix is where the images of the CSI sidecars and of the
+// HostPath plugin come from.
+const csiImagePrefix = "quay.io/k8scsi"
+
+// FioImage is used by the IO benchmark. It must contain fio and
+// /bin/sh. It is built from images/fio, in the same registry as the
+// NFS plugin image. Like all other images it can be changed with
+// --image-rewrite and, for the "fio" container, with --image-tag.
+const FioImage = "quay.io/mathu97/fio:v1.0.0"
+
+var (
+	csiImageRegistry string
+	csiImageVersion  string
+	imageRewrites    = stringMap{}
+	containerTags    = stringMap{}
+)
+
+func init() {
+	flag.StringVar(&csiImageRegistry, "storage.csi.image.registry", "", "registry that replaces "+csiImagePrefix+" in the images of deployed drivers")
+	flag.StringVar(&csiImageVersion, "storage.csi.image.version", "", "tag for all "+csiImagePrefix+" images of deployed drivers")
+	flag.Var(&imageRewrites, "image-rewrite", "<old prefix>=<new prefix> replaces the beginning of image names in deployed drivers, can be given more than once")
+	flag.Var(&containerTags, "image-tag", "<container name>=<tag> sets the image tag of all containers with that name in deployed drivers, can be given more than once")
+}
+
+// stringMap is a flag.Value that collects key=value pairs.
+type stringMap map[string]string
+
+func (m stringMap) String() string {
+	var pairs []string
+	for key, value := range m {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (m stringMap) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("expected <key>=<value>, got %q", value)
+	}
+	m[parts[0]] = parts[1]
+	return nil
+}
+
+// PatchImages rewrites the images of all containers in the item
+// according to the command line flags. It can be used directly as
+// callback for Framework.CreateFromManifests or be called by such a
+// callback after other patching.
+func PatchImages(item interface{}) error {
+	switch item := item.(type) {
+	case *v1.Pod:
+		patchPodSpec(&item.Spec)
+	case *apps.StatefulSet:
+		patchPodSpec(&item.Spec.Template.Spec)
+	case *apps.DaemonSet:
+		patchPodSpec(&item.Spec.Template.Spec)
+	case *apps.Deployment:
+		patchPodSpec(&item.Spec.Template.Spec)
+	}
+	return nil
+}
+
+func patchPodSpec(spec *v1.PodSpec) {
+	for i := range spec.InitContainers {
+		container := &spec.InitContainers[i]
+		container.Image = RewriteImage(container.Name, container.Image)
+	}
+	for i := range spec.Containers {
+		container := &spec.Containers[i]
+		container.Image = RewriteImage(container.Name, container.Image)
+	}
+}
+
+// RewriteImage returns the image that a container with the given name
+// should use instead of image. The longest matching --image-rewrite
+// prefix wins over --storage.csi.image.registry, and --image-tag for the
+// container wins over --storage.csi.image.version. A digest is kept
+// unless one of the tag overrides applies, because it would pin the
+// original image.
+func RewriteImage(containerName, image string) string {
+	name, tag, digest := splitImage(image)
+	fromCSI := hasImagePrefix(name, csiImagePrefix)
+
+	if prefix := longestImagePrefix(name); prefix != "" {
+		name = imageRewrites[prefix] + strings.TrimPrefix(name, prefix)
+	} else if fromCSI && csiImageRegistry != "" {
+		name = csiImageRegistry + strings.TrimPrefix(name, csiImagePrefix)
+	}
+
+	if override, ok := containerTags[containerName]; ok {
+		tag, digest = override, ""
+	} else if fromCSI && csiImageVersion != "" {
+		tag, digest = csiImageVersion, ""
+	}
+
+	if tag != "" {
+		name += ":" + tag
+	}
+	if digest != "" {
+		name += "@" + digest
+	}
+	return name
+}
+
+// splitImage separates the tag and the digest from an image name.
+// Both are optional.
+func splitImage(image string) (name, tag, digest string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, digest = image[:i], image[i+1:]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:], digest
+	}
+	return image, "", digest
+}
+
+// hasImagePrefix checks for a prefix that ends at a path component.
+func hasImagePrefix(name, prefix string) bool {
+	return name == prefix || strings.HasPrefix(name, strings.TrimSuffix(prefix, "/")+"/")
+}
+
+func longestImagePrefix(name string) string {
+	longest := ""
+	for prefix := range imageRewrites {
+		if hasImagePrefix(name, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	return longest
+}
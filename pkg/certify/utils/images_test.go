@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestRewriteImage(t *testing.T) {
+	defer func() {
+		csiImageRegistry, csiImageVersion = "", ""
+		imageRewrites, containerTags = stringMap{}, stringMap{}
+	}()
+
+	csiImageRegistry = "mirror.internal/csi"
+	csiImageVersion = "canary"
+	imageRewrites = stringMap{
+		"quay.io":         "registry.internal/quay",
+		"quay.io/mathu97": "registry.internal/nfs",
+	}
+	containerTags = stringMap{"csi-attacher": "v1.1.0"}
+
+	for _, test := range []struct {
+		container, image, expected string
+	}{
+		// --image-rewrite wins over the registry, but the CSI version still applies.
+		{"csi-provisioner", "quay.io/k8scsi/csi-provisioner:v1.0.1", "registry.internal/quay/k8scsi/csi-provisioner:canary"},
+		// Longest prefix wins, per-container tag wins.
+		{"nfs", "quay.io/mathu97/nfsplugin:v1.0.0", "registry.internal/nfs/nfsplugin:v1.0.0"},
+		// Digests are kept unless a tag override applies.
+		{"csi-attacher", "quay.io/mathu97/nfsplugin@sha256:abcd", "registry.internal/nfs/nfsplugin:v1.1.0"},
+		{"nfs", "quay.io/mathu97/nfsplugin@sha256:abcd", "registry.internal/nfs/nfsplugin@sha256:abcd"},
+		{"nfs", "quay.io/mathu97/nfsplugin:v1.0.0@sha256:abcd", "registry.internal/nfs/nfsplugin:v1.0.0@sha256:abcd"},
+		{"csi-provisioner", "quay.io/k8scsi/csi-provisioner:v1.0.1@sha256:abcd", "registry.internal/quay/k8scsi/csi-provisioner:canary"},
+		{"registry", "localhost:5000/foo@sha256:abcd", "localhost:5000/foo@sha256:abcd"},
+		// Prefixes must end at a path component.
+		{"nfs", "quay.io.example.com/nfs:v1", "quay.io.example.com/nfs:v1"},
+		{"server", "gcr.io/kubernetes-e2e-test-images/volume/nfs:1.0", "gcr.io/kubernetes-e2e-test-images/volume/nfs:1.0"},
+		{"registry", "localhost:5000/foo", "localhost:5000/foo"},
+	} {
+		if actual := RewriteImage(test.container, test.image); actual != test.expected {
+			t.Errorf("%s %s: expected %s, got %s", test.container, test.image, test.expected, actual)
+		}
+	}
+
+	imageRewrites = stringMap{}
+	if actual := RewriteImage("hostpath", "quay.io/k8scsi/hostpathplugin:v1.0.1"); actual != "mirror.internal/csi/hostpathplugin:canary" {
+		t.Errorf("expected registry and version override, got %s", actual)
+	}
+
+	csiImageVersion = ""
+	if actual := RewriteImage("hostpath", "quay.io/k8scsi/hostpathplugin@sha256:abcd"); actual != "mirror.internal/csi/hostpathplugin@sha256:abcd" {
+		t.Errorf("expected registry override with digest, got %s", actual)
+	}
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"k8s.io/kubernetes/test/e2e/framework"
+	"k8s.io/kubernetes/test/e2e/storage/testpatterns"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+)
+
+// SkipUnsupportedTest mirrors the check that testsuites.DefineTestSuite
+// does before running a suite with a pattern, which is not exported:
+// the interfaces implemented by the driver and its supported fsTypes
+// are checked first, then the driver itself gets asked.
+func SkipUnsupportedTest(driver testsuites.TestDriver, pattern testpatterns.TestPattern) {
+	dInfo := driver.GetDriverInfo()
+	isSupported := false
+	if pattern.SnapshotType != "" {
+		if pattern.SnapshotType == testpatterns.DynamicCreatedSnapshot {
+			_, isSupported = driver.(testsuites.SnapshottableTestDriver)
+		}
+		if !isSupported {
+			framework.Skipf("Driver %s doesn't support snapshot type %v -- skipping", dInfo.Name, pattern.SnapshotType)
+		}
+	} else {
+		switch pattern.VolType {
+		case testpatterns.InlineVolume:
+			_, isSupported = driver.(testsuites.InlineVolumeTestDriver)
+		case testpatterns.PreprovisionedPV:
+			_, isSupported = driver.(testsuites.PreprovisionedPVTestDriver)
+		case testpatterns.DynamicPV:
+			_, isSupported = driver.(testsuites.DynamicPVTestDriver)
+		}
+		if !isSupported {
+			framework.Skipf("Driver %s doesn't support %v -- skipping", dInfo.Name, pattern.VolType)
+		}
+		if !dInfo.SupportedFsType.Has(pattern.FsType) {
+			framework.Skipf("Driver %s doesn't support %v -- skipping", dInfo.Name, pattern.FsType)
+		}
+		if pattern.FsType == "xfs" && framework.NodeOSDistroIs("gci") {
+			framework.Skipf("Distro doesn't support xfs -- skipping")
+		}
+	}
+	driver.SkipUnsupportedTest(pattern)
+}
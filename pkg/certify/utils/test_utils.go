@@ -1,9 +1,17 @@
 package utils
 
 import (
-	"github.com/wongma7/csi-certify/pkg/certify/driver"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
 	"k8s.io/kubernetes/test/e2e/storage/testsuites"
 )
 
@@ -14,12 +22,35 @@ var CSITestSuites = []func() testsuites.TestSuite{
 	testsuites.InitVolumeModeTestSuite,
 	testsuites.InitSubPathTestSuite,
 	testsuites.InitProvisioningTestSuite,
+	testsuites.InitSnapshottableTestSuite,
 }
 
-var CSITestDrivers = map[string]func() testsuites.TestDriver{
-	"hostpath": driver.InitHostPathCSIDriver,
-	"nfs":      driver.InitNFSDriver,
-}
+// Capabilities which are only checked by the csi-certify test suites.
+// They can be set in DriverInfo.Capabilities like the ones defined by
+// the testsuites package.
+const (
+	// CapAttachLimit means that the node plugin reports the maximum
+	// number of volumes per node in NodeGetInfo.
+	CapAttachLimit testsuites.Capability = "attachLimit"
+
+	// CapVolumeStats means that the node plugin implements
+	// NodeGetVolumeStats, so kubelet reports capacity and usage of
+	// mounted volumes.
+	CapVolumeStats testsuites.Capability = "volumeStats"
+
+	// CapReadWriteMany means that volumes can be mounted read/write
+	// by pods on different nodes at the same time.
+	CapReadWriteMany testsuites.Capability = "readWriteMany"
+
+	// CapReadOnlyMany means that volumes can be mounted read-only by
+	// pods on different nodes at the same time.
+	CapReadOnlyMany testsuites.Capability = "readOnlyMany"
+
+	// CapCloning means that the driver can create a volume with the
+	// content of another volume, i.e. from a PVC with a PVC as
+	// dataSource.
+	CapCloning testsuites.Capability = "cloning"
+)
 
 // DriverDefinition needs to be filled in via a .yaml or .json
 // file. It's methods then implement the TestDriver interface, using
@@ -73,6 +104,262 @@ type DriverDefinition struct {
 	// Can be left empty. Most drivers should not need this and instead
 	// use topology to ensure that pods land on the right node(s).
 	ClientNodeName string
+
+	// VolumeStats defines how much the volume statistics reported by
+	// kubelet may deviate from the expected values. Only used when
+	// DriverInfo.Capabilities enables volumeStats.
+	VolumeStats VolumeStatsTolerances
+
+	// NodePlugin identifies the pods of the driver's node plugin.
+	// Disruptive tests which restart the node plugin are skipped
+	// unless LabelSelector is set.
+	NodePlugin PodSelector
+
+	// ControllerPlugin identifies the pods which run the external
+	// provisioner together with the driver's controller service.
+	// Tests which restart them during provisioning are skipped unless
+	// LabelSelector is set.
+	ControllerPlugin PodSelector
+
+	// VolumeLister lists the volumes in the storage backend. The
+	// tests which restart the controller use it to detect volumes
+	// that were created twice or not deleted. They only check PVs
+	// when Command is empty.
+	VolumeLister PodCommand
+
+	// Scale enables the scale test which provisions many volumes
+	// at once. It is skipped unless Count is set.
+	Scale ScaleParameters
+
+	// IOBenchmark configures the optional IO benchmark and sets
+	// the minimum performance that the driver must achieve.
+	IOBenchmark IOBenchmarkParameters
+}
+
+// PodSelector identifies a set of pods by label.
+type PodSelector struct {
+	// Namespace of the pods. The test namespace is used when empty,
+	// which is where TestDrivers deploy the driver in PrepareTest.
+	Namespace string
+
+	// LabelSelector, for example "app=csi-nodeplugin-nfsplugin".
+	LabelSelector string
+}
+
+// PodCommand is a shell command that gets executed in a container of
+// each of the selected pods.
+type PodCommand struct {
+	Pods PodSelector
+
+	// Container is the name of the container. Can be left empty for
+	// pods with only one container.
+	Container string
+
+	// Command is passed to /bin/sh -c. For VolumeLister it must
+	// print one volume per line.
+	Command string
+}
+
+// VolumeStatsTolerances are used by the volume stats suite when
+// comparing the statistics reported by kubelet against the size of a
+// volume and the amount of data written into it.
+type VolumeStatsTolerances struct {
+	// UsedBytes is how much the increase of the used bytes may
+	// differ from the amount of data written, for example because of
+	// file system metadata. Default is "16Mi".
+	UsedBytes *resource.Quantity
+
+	// CapacityPercent is how much smaller than the size of the
+	// PersistentVolume the capacity may be, for example because of
+	// file system overhead. It may never be larger. Default is 10.
+	CapacityPercent *int
+}
+
+// ScaleParameters define how many volumes the scale suite creates
+// and how many of them at the same time.
+type ScaleParameters struct {
+	// Count is the total number of PVCs and pods.
+	Count int
+
+	// Concurrency is the number of PVCs and pods that get created
+	// or deleted in parallel. Default is 10.
+	Concurrency int
+}
+
+// IOBenchmarkParameters are used by the IO benchmark suite, which
+// runs fio with each of the IOBenchmark* workloads.
+type IOBenchmarkParameters struct {
+	// FileSize is the size of the file used by fio. It must fit
+	// into a volume of the claim size. Default is the smallest of
+	// 1Gi, DriverInfo.MaxFileSize and half of the claim size.
+	FileSize *resource.Quantity
+
+	// Runtime of each workload. Default is 30s.
+	Runtime *metav1.Duration
+
+	// Thresholds are the minimum results per workload. Results get
+	// recorded for all workloads, but only checked against the
+	// thresholds that are set.
+	Thresholds map[string]IOBenchmarkThresholds
+}
+
+// Workloads of the IO benchmark suite.
+const (
+	IOBenchmarkSequentialWrite = "sequential-write"
+	IOBenchmarkSequentialRead  = "sequential-read"
+	IOBenchmarkRandomWrite     = "random-write-4k"
+	IOBenchmarkRandomRead      = "random-read-4k"
+)
+
+// Validate checks that thresholds are only set for known workloads,
+// so that a typo does not silently disable a check, and that FileSize
+// is not larger than the volumes of claimSize. An empty claimSize
+// skips that check.
+func (p IOBenchmarkParameters) Validate(claimSize string) error {
+	if p.FileSize != nil && claimSize != "" {
+		claim, err := resource.ParseQuantity(claimSize)
+		if err != nil {
+			return errors.Wrapf(err, "claim size %q", claimSize)
+		}
+		if p.FileSize.Cmp(claim) > 0 {
+			return errors.Errorf("IOBenchmark.FileSize: %s is larger than the claim size %s", p.FileSize.String(), claimSize)
+		}
+	}
+	known := sets.NewString(IOBenchmarkSequentialWrite, IOBenchmarkSequentialRead, IOBenchmarkRandomWrite, IOBenchmarkRandomRead)
+	var unknown []string
+	for workload := range p.Thresholds {
+		if !known.Has(workload) {
+			unknown = append(unknown, workload)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return errors.Errorf("IOBenchmark.Thresholds: unknown workloads %s, must be one of %s", strings.Join(unknown, ", "), strings.Join(known.List(), ", "))
+	}
+	return nil
+}
+
+// IOBenchmarkThresholds define when an IO benchmark workload fails.
+// Zero values disable the check.
+type IOBenchmarkThresholds struct {
+	// MinIOPS is the minimum number of IO operations per second.
+	MinIOPS float64
+
+	// MinBandwidth is the minimum number of bytes per second.
+	MinBandwidth *resource.Quantity
+
+	// MaxLatency is the maximum mean completion latency of a single
+	// IO operation.
+	MaxLatency *metav1.Duration
+}
+
+// VolumeStatsTestDriver can be implemented by TestDrivers which
+// declare CapVolumeStats and need other tolerances than the defaults.
+// DriverDefinition implements it.
+type VolumeStatsTestDriver interface {
+	GetVolumeStatsTolerances() VolumeStatsTolerances
+}
+
+var _ VolumeStatsTestDriver = &DriverDefinition{}
+
+func (d *DriverDefinition) GetVolumeStatsTolerances() VolumeStatsTolerances {
+	return d.VolumeStats
+}
+
+// NodePluginTestDriver is implemented by TestDrivers whose node plugin
+// may get restarted by disruptive tests. DriverDefinition implements
+// it.
+type NodePluginTestDriver interface {
+	// GetNodePlugin returns the selector for the node plugin pods
+	// that were deployed for the test.
+	GetNodePlugin(config *testsuites.PerTestConfig) PodSelector
+}
+
+var _ NodePluginTestDriver = &DriverDefinition{}
+
+func (d *DriverDefinition) GetNodePlugin(config *testsuites.PerTestConfig) PodSelector {
+	return d.NodePlugin
+}
+
+// ControllerPluginTestDriver is implemented by TestDrivers whose
+// provisioner may get restarted by disruptive tests. DriverDefinition
+// implements it.
+type ControllerPluginTestDriver interface {
+	// GetControllerPlugin returns the selector for the provisioner
+	// pods that were deployed for the test.
+	GetControllerPlugin(config *testsuites.PerTestConfig) PodSelector
+
+	// GetVolumeLister returns the command which lists the volumes
+	// in the storage backend, or an empty Command if the driver
+	// cannot do that.
+	GetVolumeLister(config *testsuites.PerTestConfig) PodCommand
+}
+
+var _ ControllerPluginTestDriver = &DriverDefinition{}
+
+func (d *DriverDefinition) GetControllerPlugin(config *testsuites.PerTestConfig) PodSelector {
+	return d.ControllerPlugin
+}
+
+func (d *DriverDefinition) GetVolumeLister(config *testsuites.PerTestConfig) PodCommand {
+	return d.VolumeLister
+}
+
+// ScaleTestDriver is implemented by TestDrivers which support the
+// scale suite. DriverDefinition implements it.
+type ScaleTestDriver interface {
+	// GetScaleParameters returns the number of volumes for the
+	// scale suite, which gets skipped when Count is zero.
+	GetScaleParameters() ScaleParameters
+}
+
+var _ ScaleTestDriver = &DriverDefinition{}
+
+func (d *DriverDefinition) GetScaleParameters() ScaleParameters {
+	return d.Scale
+}
+
+// IOBenchmarkTestDriver is implemented by TestDrivers which configure
+// the IO benchmark suite. It runs with the defaults for all other
+// drivers. DriverDefinition implements it.
+type IOBenchmarkTestDriver interface {
+	GetIOBenchmarkParameters() IOBenchmarkParameters
+}
+
+var _ IOBenchmarkTestDriver = &DriverDefinition{}
+
+func (d *DriverDefinition) GetIOBenchmarkParameters() IOBenchmarkParameters {
+	return d.IOBenchmark
+}
+
+// LoadDriverDefinition reads a DriverDefinition from a .yaml or .json
+// file, like the one given to --driverdef.
+func LoadDriverDefinition(filename string) (*DriverDefinition, error) {
+	if filename == "" {
+		return nil, errors.New("missing file name")
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	// Some reasonable defaults follow.
+	driver := &DriverDefinition{
+		DriverInfo: testsuites.DriverInfo{
+			SupportedFsType: sets.NewString(
+				"", // Default fsType
+			),
+		},
+		ClaimSize: "5Gi",
+	}
+	// TODO: strict checking of the file content once https://github.com/kubernetes/kubernetes/pull/71589
+	// or something similar is merged.
+	if err := runtime.DecodeInto(legacyscheme.Codecs.UniversalDecoder(), data, driver); err != nil {
+		return nil, errors.Wrap(err, filename)
+	}
+	if err := driver.IOBenchmark.Validate(driver.ClaimSize); err != nil {
+		return nil, errors.Wrap(err, filename)
+	}
+	return driver, nil
 }
 
 func (d *DriverDefinition) DeepCopyObject() runtime.Object {
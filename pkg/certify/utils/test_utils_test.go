@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoadDriverDefinitionIOBenchmark(t *testing.T) {
+	for _, test := range []struct {
+		name, ioBenchmark, err string
+	}{
+		{name: "known", ioBenchmark: "Thresholds:\n    sequential-write: {MinIOPS: 10}\n    random-read-4k: {MinIOPS: 10}"},
+		{name: "unknown", ioBenchmark: "Thresholds:\n    sequential-write: {MinIOPS: 10}\n    random-read: {MinIOPS: 10}",
+			err: "IOBenchmark.Thresholds: unknown workloads random-read, must be one of random-read-4k, random-write-4k, sequential-read, sequential-write"},
+		// The default claim size is 5Gi.
+		{name: "file fits", ioBenchmark: "FileSize: 5Gi"},
+		{name: "file too large", ioBenchmark: "FileSize: 6Gi",
+			err: "IOBenchmark.FileSize: 6Gi is larger than the claim size 5Gi"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := ioutil.TempFile("", "driverdef")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(file.Name())
+			definition := "DriverInfo:\n  Name: example\nIOBenchmark:\n  " + test.ioBenchmark + "\n"
+			if _, err := file.WriteString(definition); err != nil {
+				t.Fatal(err)
+			}
+			file.Close()
+
+			_, err = LoadDriverDefinition(file.Name())
+			switch {
+			case test.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+				t.Fatalf("expected error %q, got: %v", test.err, err)
+			}
+		})
+	}
+}